@@ -0,0 +1,8 @@
+# 重新安装安装失败的集群（需要 Terraform 1.14+）
+# 执行方式：terraform apply -invoke=action.zstack_cluster_recreate.reinstall
+action "zstack_cluster_recreate" "reinstall" {
+  config {
+    cluster_id = zstack_cluster.example.id
+    timeout    = "2h"
+  }
+}
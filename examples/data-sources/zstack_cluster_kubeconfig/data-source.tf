@@ -0,0 +1,26 @@
+# 获取集群 kubeconfig，并直接用于配置 kubernetes / helm provider
+data "zstack_cluster_kubeconfig" "example" {
+  cluster_id = 1
+}
+
+provider "kubernetes" {
+  host                   = data.zstack_cluster_kubeconfig.example.host
+  cluster_ca_certificate = data.zstack_cluster_kubeconfig.example.cluster_ca_certificate
+  client_certificate     = data.zstack_cluster_kubeconfig.example.client_certificate
+  client_key             = data.zstack_cluster_kubeconfig.example.client_key
+}
+
+provider "helm" {
+  kubernetes {
+    host                   = data.zstack_cluster_kubeconfig.example.host
+    cluster_ca_certificate = data.zstack_cluster_kubeconfig.example.cluster_ca_certificate
+    client_certificate     = data.zstack_cluster_kubeconfig.example.client_certificate
+    client_key             = data.zstack_cluster_kubeconfig.example.client_key
+  }
+}
+
+# 原始 kubeconfig 内容
+output "kubeconfig" {
+  value     = data.zstack_cluster_kubeconfig.example.kubeconfig_raw
+  sensitive = true
+}
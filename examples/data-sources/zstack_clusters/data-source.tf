@@ -1,8 +1,5 @@
-# 查询所有集群列表
-data "zstack_clusters" "all" {
-  limit  = 20
-  offset = 0
-}
+# 查询所有集群列表（自动分页）
+data "zstack_clusters" "all" {}
 
 # 输出集群列表
 output "all_clusters" {
@@ -14,12 +11,34 @@ output "total_clusters" {
   description = "集群总数"
 }
 
-# 输出第一个集群的信息（如果存在）
-output "first_cluster" {
-  value = length(data.zstack_clusters.all.clusters) > 0 ? {
-    id     = data.zstack_clusters.all.clusters[0].id
-    name   = data.zstack_clusters.all.clusters[0].name
-    status = data.zstack_clusters.all.clusters[0].status
-  } : null
-  description = "第一个集群的基本信息"
+# 按名称前缀和状态过滤，按创建时间倒序排列
+data "zstack_clusters" "prod" {
+  name_prefix = "prod-"
+  status      = "Running"
+  sort_by     = "create_time"
+  sort_order  = "desc"
+}
+
+output "prod_cluster_ids" {
+  value = data.zstack_clusters.prod.ids
+}
+
+# 按名称查找集群 ID
+output "prod_edge_01_id" {
+  value = try(data.zstack_clusters.prod.by_name["prod-edge-01"].id, null)
+}
+
+# 手动分页：只查询一页
+data "zstack_clusters" "page" {
+  limit  = 20
+  offset = 0
+}
+
+# 通用 filter 块：节点数不少于 3 的集群
+data "zstack_clusters" "large" {
+  filter {
+    name     = "node_count"
+    values   = ["3"]
+    operator = ">="
+  }
 }
@@ -0,0 +1,17 @@
+data "zstack_external_network_interfaces" "example" {
+  cluster_id = 1
+  refresh    = true
+}
+
+# 使用所有节点上都存在的第一块网卡创建外部网络
+resource "zstack_external_network" "example" {
+  cluster_id = 1
+  name       = "ext-net"
+  gateway    = "192.168.10.1"
+  netmask    = "255.255.255.0"
+  interface  = data.zstack_external_network_interfaces.example.same_ifaces[0]
+}
+
+output "node_interfaces" {
+  value = { for node in data.zstack_external_network_interfaces.example.nodes : node.name => node.ifaces }
+}
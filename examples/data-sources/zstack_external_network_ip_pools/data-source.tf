@@ -0,0 +1,36 @@
+data "zstack_external_network_ip_pools" "svc" {
+  cluster_id          = 1
+  external_network_id = 2
+  ip_pool_type        = "Svc"
+}
+
+# 服务 IP 池使用率超过 80% 时告警
+check "svc_ip_pool_capacity" {
+  assert {
+    condition     = data.zstack_external_network_ip_pools.svc.utilization_percent < 80
+    error_message = "Service IP pools are ${data.zstack_external_network_ip_pools.svc.utilization_percent}% used."
+  }
+}
+
+output "svc_pool_usage" {
+  value = {
+    for pool in data.zstack_external_network_ip_pools.svc.pools :
+    pool.name => "${pool.ip_used_num}/${pool.ip_total_num} (${pool.utilization_percent}%)"
+  }
+}
+
+# 通用 filter 块：未禁用且已有 IP 被使用的 IP 池
+data "zstack_external_network_ip_pools" "in_use" {
+  cluster_id          = 1
+  external_network_id = 2
+
+  filter {
+    name   = "disabled"
+    values = ["false"]
+  }
+
+  filter {
+    name   = "exist_ip_used"
+    values = ["true"]
+  }
+}
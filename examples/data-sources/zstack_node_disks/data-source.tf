@@ -0,0 +1,35 @@
+variable "ssh_password" {
+  type      = string
+  sensitive = true
+}
+
+# 查询各主机的候选数据盘
+data "zstack_node_disks" "example" {
+  password = var.ssh_password
+
+  hosts = [
+    {
+      name = "master-node-1"
+      ip   = "172.31.13.101"
+    },
+    {
+      name = "worker-node-1"
+      ip   = "172.31.13.102"
+      port = 2222
+    },
+  ]
+}
+
+# 各节点所有未使用的磁盘，可直接作为 zstack_cluster 的 data_disk
+output "unused_disks" {
+  value = data.zstack_node_disks.example.unused_disks
+}
+
+# 按容量筛选：仅使用大于 500GiB 的未使用磁盘
+output "large_unused_disks" {
+  value = {
+    for host in data.zstack_node_disks.example.hosts : host.name => [
+      for disk in host.disks : disk.path if !disk.used && disk.size > 500 * 1024 * 1024 * 1024
+    ]
+  }
+}
@@ -0,0 +1,17 @@
+data "zstack_project_service_networks" "tenant" {
+  cluster_id = 1
+  project_id = 3
+  ready_only = true
+}
+
+# 剩余 IP 最多的网络
+output "service_network" {
+  value = data.zstack_project_service_networks.tenant.networks[0].name
+}
+
+output "service_network_usage" {
+  value = {
+    for network in data.zstack_project_service_networks.tenant.networks :
+    network.name => "${network.ip_used_num}/${network.ip_total_num} (${network.utilization_percent}%)"
+  }
+}
@@ -0,0 +1,19 @@
+# 查询项目下的全部镜像仓库（自动分页）
+data "zstack_repositories" "all" {
+  project_id = 1
+}
+
+output "repository_names" {
+  value = [for repo in data.zstack_repositories.all.repositories : repo.name]
+}
+
+# 通用 filter 块：名称以 app- 开头的仓库
+data "zstack_repositories" "apps" {
+  project_id = 1
+
+  filter {
+    name     = "name"
+    values   = ["app-%"]
+    operator = "like"
+  }
+}
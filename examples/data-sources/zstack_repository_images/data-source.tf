@@ -0,0 +1,20 @@
+data "zstack_repositories" "apps" {
+  project_id = 1
+  name       = "apps"
+}
+
+# 通用 filter 块：仓库中至少有 2 个版本的镜像
+data "zstack_repository_images" "multi_tag" {
+  project_id    = 1
+  repository_id = data.zstack_repositories.apps.repositories[0].id
+
+  filter {
+    name     = "tag_count"
+    values   = ["2"]
+    operator = ">="
+  }
+}
+
+output "image_names" {
+  value = [for image in data.zstack_repository_images.multi_tag.images : image.name]
+}
@@ -0,0 +1,20 @@
+# 获取集群 kubeconfig（需要 Terraform 1.10+），凭据不会写入 plan 和 state
+ephemeral "zstack_cluster_kubeconfig" "example" {
+  cluster_id = 1
+}
+
+provider "kubernetes" {
+  host                   = ephemeral.zstack_cluster_kubeconfig.example.host
+  cluster_ca_certificate = ephemeral.zstack_cluster_kubeconfig.example.cluster_ca_certificate
+  client_certificate     = ephemeral.zstack_cluster_kubeconfig.example.client_certificate
+  client_key             = ephemeral.zstack_cluster_kubeconfig.example.client_key
+}
+
+provider "helm" {
+  kubernetes {
+    host                   = ephemeral.zstack_cluster_kubeconfig.example.host
+    cluster_ca_certificate = ephemeral.zstack_cluster_kubeconfig.example.cluster_ca_certificate
+    client_certificate     = ephemeral.zstack_cluster_kubeconfig.example.client_certificate
+    client_key             = ephemeral.zstack_cluster_kubeconfig.example.client_key
+  }
+}
@@ -1,10 +1,23 @@
+variable "ssh_password" {
+  type      = string
+  sensitive = true
+}
+
+variable "iluvatar_license" {
+  type      = string
+  sensitive = true
+}
+
 # 创建一个 ZStack Edge 集群
 resource "zstack_cluster" "example" {
   name             = "my-k8s-cluster"
   enable_ha        = true
   net_combined     = false
   port             = 22
-  password         = "encrypted_password_here"
+  # 推荐使用 write-only 属性（Terraform 1.11+），密码不会写入 plan 和 state
+  # 修改密码时需同时递增 password_wo_version
+  password_wo         = var.ssh_password
+  password_wo_version = 1
   
   # 网络配置
   management_vip_v4 = "172.31.13.100"
@@ -17,12 +30,18 @@ resource "zstack_cluster" "example" {
   k8s_version      = "1.24"
   max_pod_per_node = 110
   
+  # 删除保护（默认开启）。删除集群前需先设置为 false 并 apply
+  deletion_protection = true
+
+  # 可选：创建前预检 SSH 凭据、数据盘和天数 GPU License
+  preflight = true
+
   # 可选：启用 Istio
   istio_enabled = true
   
   # 可选：天数 GPU 配置
   iluvatar_gpu_model = "BI-V100"
-  iluvatar_license   = "your_license_key_here"
+  iluvatar_license_wo = var.iluvatar_license
   
   # 集群节点配置
   nodes = [
@@ -0,0 +1,34 @@
+variable "ssh_password" {
+  type      = string
+  sensitive = true
+}
+
+variable "workers" {
+  type = map(object({
+    ip    = string
+    disks = list(string)
+  }))
+  default = {
+    "worker-node-3" = { ip = "172.31.13.104", disks = ["/dev/sdd"] }
+    "worker-node-4" = { ip = "172.31.13.105", disks = ["/dev/sdd"] }
+  }
+}
+
+# 每个节点一个资源，增删 map 中的条目只会影响对应的节点
+resource "zstack_cluster_node" "worker" {
+  for_each = var.workers
+
+  cluster_id = 1
+  name       = each.key
+  ip         = each.value.ip
+  roles      = ["Worker"]
+
+  password_wo         = var.ssh_password
+  password_wo_version = 1
+
+  image_data_disk = each.value.disks
+}
+
+output "worker_status" {
+  value = { for name, node in zstack_cluster_node.worker : name => node.status }
+}
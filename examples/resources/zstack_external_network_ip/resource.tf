@@ -0,0 +1,18 @@
+# 使用指定地址，地址已被占用时创建失败
+resource "zstack_external_network_ip" "api" {
+  cluster_id            = 1
+  external_network_name = "ext-net"
+  address               = "192.168.10.120"
+}
+
+# 自动选取可用地址。平台没有预留接口，先创建指定地址的资源，避免自动选取时拿走该地址
+resource "zstack_external_network_ip" "ingress" {
+  cluster_id            = 1
+  external_network_name = "ext-net"
+
+  depends_on = [zstack_external_network_ip.api]
+}
+
+output "ingress_load_balancer_ip" {
+  value = zstack_external_network_ip.ingress.address
+}
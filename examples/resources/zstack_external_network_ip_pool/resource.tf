@@ -0,0 +1,33 @@
+resource "zstack_external_network" "example" {
+  cluster_id = 1
+  name       = "ext-net"
+  gateway    = "192.168.10.1"
+  netmask    = "255.255.255.0"
+  interface  = "eth1"
+}
+
+# 所有项目可用的服务 IP 池
+resource "zstack_external_network_ip_pool" "svc" {
+  cluster_id          = zstack_external_network.example.cluster_id
+  external_network_id = zstack_external_network.example.id
+  name                = "svc-pool"
+  ip_pool_type        = "Svc"
+  start_ip            = "192.168.10.100"
+  end_ip              = "192.168.10.149"
+}
+
+# 仅分配给指定项目的容器组 IP 池
+resource "zstack_external_network_ip_pool" "pod" {
+  cluster_id          = zstack_external_network.example.cluster_id
+  external_network_id = zstack_external_network.example.id
+  name                = "pod-pool"
+  ip_pool_type        = "Pod"
+  start_ip            = "192.168.10.150"
+  end_ip              = "192.168.10.199"
+  share_type          = "assign"
+  project_ids         = [3, 5]
+}
+
+output "svc_pool_usage" {
+  value = "${zstack_external_network_ip_pool.svc.ip_used_num}/${zstack_external_network_ip_pool.svc.ip_total_num}"
+}
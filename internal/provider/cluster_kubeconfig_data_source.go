@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"zstack.io/edge-go-sdk/pkg/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ClusterKubeconfigDataSource{}
+
+func NewClusterKubeconfigDataSource() datasource.DataSource {
+	return &ClusterKubeconfigDataSource{}
+}
+
+// ClusterKubeconfigDataSource defines the data source implementation.
+type ClusterKubeconfigDataSource struct {
+	client *client.ZeClient
+}
+
+// ClusterKubeconfigModel describes the kubeconfig data model.
+type ClusterKubeconfigModel struct {
+	ClusterID            types.Int64  `tfsdk:"cluster_id"`
+	KubeconfigRaw        types.String `tfsdk:"kubeconfig_raw"`
+	Host                 types.String `tfsdk:"host"`
+	ClusterCACertificate types.String `tfsdk:"cluster_ca_certificate"`
+	ClientCertificate    types.String `tfsdk:"client_certificate"`
+	ClientKey            types.String `tfsdk:"client_key"`
+	Token                types.String `tfsdk:"token"`
+}
+
+func (d *ClusterKubeconfigDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster_kubeconfig"
+}
+
+func (d *ClusterKubeconfigDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "获取 ZStack Edge 集群 kubeconfig 的数据源。除原始 kubeconfig 外，还解析出可直接用于 kubernetes / helm provider 的连接信息。" +
+			"注意：数据源的结果会写入 state，如需避免凭据落盘，请使用同名的 ephemeral resource。",
+
+		Attributes: map[string]schema.Attribute{
+			"cluster_id": schema.Int64Attribute{
+				MarkdownDescription: "集群 ID",
+				Required:            true,
+			},
+			"kubeconfig_raw": schema.StringAttribute{
+				MarkdownDescription: "原始 kubeconfig 内容",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"host": schema.StringAttribute{
+				MarkdownDescription: "Kubernetes API Server 地址",
+				Computed:            true,
+			},
+			"cluster_ca_certificate": schema.StringAttribute{
+				MarkdownDescription: "集群 CA 证书（PEM 格式）",
+				Computed:            true,
+			},
+			"client_certificate": schema.StringAttribute{
+				MarkdownDescription: "客户端证书（PEM 格式）",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"client_key": schema.StringAttribute{
+				MarkdownDescription: "客户端私钥（PEM 格式）",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "访问令牌（kubeconfig 中未配置时为空）",
+				Computed:            true,
+				Sensitive:           true,
+			},
+		},
+	}
+}
+
+func (d *ClusterKubeconfigDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.ZeClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.ZeClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ClusterKubeconfigDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ClusterKubeconfigModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterID := int(data.ClusterID.ValueInt64())
+
+	tflog.Info(ctx, "Reading cluster kubeconfig", map[string]interface{}{
+		"cluster_id": clusterID,
+	})
+
+	raw, creds, err := readClusterKubeconfig(d.client, clusterID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading cluster kubeconfig", err.Error())
+		return
+	}
+
+	data.setKubeconfig(raw, creds)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// setKubeconfig 将 kubeconfig 及解析结果写入模型
+func (m *ClusterKubeconfigModel) setKubeconfig(raw string, creds *kubeconfigCredentials) {
+	m.KubeconfigRaw = types.StringValue(raw)
+	m.Host = types.StringValue(creds.Host)
+	m.ClusterCACertificate = types.StringValue(creds.ClusterCACertificate)
+	m.ClientCertificate = types.StringValue(creds.ClientCertificate)
+	m.ClientKey = types.StringValue(creds.ClientKey)
+	m.Token = types.StringValue(creds.Token)
+}
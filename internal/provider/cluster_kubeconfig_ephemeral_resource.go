@@ -0,0 +1,116 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"zstack.io/edge-go-sdk/pkg/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ephemeral.EphemeralResource = &ClusterKubeconfigEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &ClusterKubeconfigEphemeralResource{}
+
+func NewClusterKubeconfigEphemeralResource() ephemeral.EphemeralResource {
+	return &ClusterKubeconfigEphemeralResource{}
+}
+
+// ClusterKubeconfigEphemeralResource defines the ephemeral resource implementation.
+type ClusterKubeconfigEphemeralResource struct {
+	client *client.ZeClient
+}
+
+func (r *ClusterKubeconfigEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster_kubeconfig"
+}
+
+func (r *ClusterKubeconfigEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "获取 ZStack Edge 集群 kubeconfig 的临时资源（需要 Terraform 1.10+）。" +
+			"结果不会写入 plan 和 state，适用于 provider 配置块和 write-only 属性，避免管理员凭据落盘。",
+
+		Attributes: map[string]schema.Attribute{
+			"cluster_id": schema.Int64Attribute{
+				MarkdownDescription: "集群 ID",
+				Required:            true,
+			},
+			"kubeconfig_raw": schema.StringAttribute{
+				MarkdownDescription: "原始 kubeconfig 内容",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"host": schema.StringAttribute{
+				MarkdownDescription: "Kubernetes API Server 地址",
+				Computed:            true,
+			},
+			"cluster_ca_certificate": schema.StringAttribute{
+				MarkdownDescription: "集群 CA 证书（PEM 格式）",
+				Computed:            true,
+			},
+			"client_certificate": schema.StringAttribute{
+				MarkdownDescription: "客户端证书（PEM 格式）",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"client_key": schema.StringAttribute{
+				MarkdownDescription: "客户端私钥（PEM 格式）",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "访问令牌（kubeconfig 中未配置时为空）",
+				Computed:            true,
+				Sensitive:           true,
+			},
+		},
+	}
+}
+
+func (r *ClusterKubeconfigEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.ZeClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *client.ZeClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ClusterKubeconfigEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data ClusterKubeconfigModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterID := int(data.ClusterID.ValueInt64())
+
+	tflog.Info(ctx, "Opening cluster kubeconfig", map[string]interface{}{
+		"cluster_id": clusterID,
+	})
+
+	raw, creds, err := readClusterKubeconfig(r.client, clusterID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading cluster kubeconfig", err.Error())
+		return
+	}
+
+	data.setKubeconfig(raw, creds)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
@@ -0,0 +1,121 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"zstack.io/edge-go-sdk/pkg/client"
+	"zstack.io/edge-go-sdk/pkg/param"
+	"zstack.io/edge-go-sdk/pkg/view"
+)
+
+const (
+	// clusterIdlePollInterval 等待集群操作结束时的查询间隔
+	clusterIdlePollInterval = 10 * time.Second
+	// clusterIdleTimeout 等待集群操作结束的最长时间
+	clusterIdleTimeout = 60 * time.Minute
+)
+
+// clusterLocks 每个集群一把锁。Terraform 会并行执行多个资源的操作，
+// 而边缘控制器无法正确处理同一集群上并发的节点增删、重装和删除。
+var clusterLocks = struct {
+	sync.Mutex
+	locks map[int]chan struct{}
+}{locks: make(map[int]chan struct{})}
+
+func clusterLock(clusterID int) chan struct{} {
+	clusterLocks.Lock()
+	defer clusterLocks.Unlock()
+
+	lock, ok := clusterLocks.locks[clusterID]
+	if !ok {
+		lock = make(chan struct{}, 1)
+		clusterLocks.locks[clusterID] = lock
+	}
+	return lock
+}
+
+// lockCluster 获取集群的锁，并等待集群上正在进行的操作结束。
+// 成功时返回解锁函数，调用方必须在操作完成后调用。
+func lockCluster(ctx context.Context, zeClient *client.ZeClient, clusterID int) (func(), error) {
+	lock := clusterLock(clusterID)
+
+	tflog.Debug(ctx, "Acquiring cluster lock", map[string]interface{}{
+		"cluster_id": clusterID,
+	})
+
+	select {
+	case lock <- struct{}{}:
+	case <-ctx.Done():
+		return nil, fmt.Errorf("interrupted while waiting for other operations on cluster %d: %w", clusterID, ctx.Err())
+	}
+	unlock := func() { <-lock }
+
+	if err := waitForClusterIdle(ctx, zeClient, clusterID); err != nil {
+		unlock()
+		return nil, err
+	}
+
+	return unlock, nil
+}
+
+// waitForClusterIdle 通过 PageClusterOperation 等待集群上正在进行的操作（包括其他客户端发起的）结束
+func waitForClusterIdle(ctx context.Context, zeClient *client.ZeClient, clusterID int) error {
+	deadline := time.Now().Add(clusterIdleTimeout)
+
+	for {
+		operation, err := runningClusterOperation(zeClient, clusterID)
+		if err != nil {
+			// 集群不存在时交给后续操作报告
+			if isNotFoundError(err) {
+				return nil
+			}
+			return fmt.Errorf("unable to query operations of cluster %d: %w", clusterID, err)
+		}
+		if operation == nil {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("cluster %d is still busy with operation %q (status %s) after %s",
+				clusterID, operation.Operation, operation.Status, clusterIdleTimeout)
+		}
+
+		tflog.Info(ctx, "Waiting for in-progress cluster operation to finish", map[string]interface{}{
+			"cluster_id": clusterID,
+			"operation":  operation.Operation,
+			"status":     operation.Status,
+		})
+
+		select {
+		case <-time.After(clusterIdlePollInterval):
+		case <-ctx.Done():
+			return fmt.Errorf("interrupted while waiting for operation %q on cluster %d: %w", operation.Operation, clusterID, ctx.Err())
+		}
+	}
+}
+
+// runningClusterOperation 返回集群最近一次尚未结束的操作，没有时返回 nil
+func runningClusterOperation(zeClient *client.ZeClient, clusterID int) (*view.ClusterOperationView, error) {
+	// 按创建时间倒序，只取最近一次操作
+	queryParam := param.NewQueryParam()
+	sortQuery(&queryParam, "createTime", true)
+	queryParam.Limit(1)
+
+	operations, _, err := zeClient.PageClusterOperation(clusterID, queryParam)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(operations) == 0 || !operations[0].FinishTime.IsZero() {
+		return nil, nil
+	}
+	return &operations[0], nil
+}
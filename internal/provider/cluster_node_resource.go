@@ -0,0 +1,634 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"zstack.io/edge-go-sdk/pkg/client"
+	"zstack.io/edge-go-sdk/pkg/param"
+	"zstack.io/edge-go-sdk/pkg/view"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ClusterNodeResource{}
+var _ resource.ResourceWithImportState = &ClusterNodeResource{}
+var _ resource.ResourceWithValidateConfig = &ClusterNodeResource{}
+
+func NewClusterNodeResource() resource.Resource {
+	return &ClusterNodeResource{}
+}
+
+// ClusterNodeResource defines the resource implementation.
+type ClusterNodeResource struct {
+	client *client.ZeClient
+}
+
+// ClusterNodeResourceModel describes the resource data model.
+type ClusterNodeResourceModel struct {
+	ID               types.String `tfsdk:"id"`
+	ClusterID        types.Int64  `tfsdk:"cluster_id"`
+	Name             types.String `tfsdk:"name"`
+	IP               types.String `tfsdk:"ip"`
+	BusinessIP       types.String `tfsdk:"business_ip"`
+	IP6              types.String `tfsdk:"ip6"`
+	Port             types.Int64  `tfsdk:"port"`
+	Roles            types.List   `tfsdk:"roles"` // []string
+	GPUProduct       types.String `tfsdk:"gpu_product"`
+	Password         types.String `tfsdk:"password"`
+	PasswordWO       types.String `tfsdk:"password_wo"`
+	PasswordWOVer    types.Int64  `tfsdk:"password_wo_version"`
+	PasswordEncoding types.String `tfsdk:"password_encoding"`
+	ContainerRuntime types.String `tfsdk:"container_runtime"`
+	DNSServer        types.String `tfsdk:"dns_server"`
+	IluvatarLicense  types.String `tfsdk:"iluvatar_license"`
+	IluvatarLicWO    types.String `tfsdk:"iluvatar_license_wo"`
+	IluvatarLicWOVer types.Int64  `tfsdk:"iluvatar_license_wo_version"`
+	ImageDataDisk    types.List   `tfsdk:"image_data_disk"` // []string
+
+	// Computed fields
+	Status  types.String `tfsdk:"status"`
+	Role    types.String `tfsdk:"role"`
+	CPU     types.String `tfsdk:"cpu"`
+	Memory  types.String `tfsdk:"memory"`
+	Storage types.String `tfsdk:"storage"`
+}
+
+func (r *ClusterNodeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster_node"
+}
+
+func (r *ClusterNodeResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "管理 ZStack Edge 集群中的单个节点。每个资源对应一个节点，可配合 `for_each` 逐个添加、删除节点，互不影响。支持通过 `<cluster_id>/<name>` 导入已有节点。",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "节点资源唯一标识符，格式为 `<cluster_id>/<name>`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cluster_id": schema.Int64Attribute{
+				MarkdownDescription: "集群 ID",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "节点名称",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ip": schema.StringAttribute{
+				MarkdownDescription: "管理网络 IPv4 地址",
+				Required:            true,
+				Validators: []validator.String{
+					ipv4Address(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"business_ip": schema.StringAttribute{
+				MarkdownDescription: "业务网络 IPv4 地址",
+				Optional:            true,
+				Validators: []validator.String{
+					ipv4Address(),
+				},
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceIfSet(),
+				},
+			},
+			"ip6": schema.StringAttribute{
+				MarkdownDescription: "IPv6 地址",
+				Optional:            true,
+				Validators: []validator.String{
+					ipv6Address(),
+				},
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceIfSet(),
+				},
+			},
+			"port": schema.Int64Attribute{
+				MarkdownDescription: "SSH 端口，默认为 22。只在添加节点时使用，修改它不会替换节点",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(defaultSSHPort),
+				Validators: []validator.Int64{
+					int64Between(1, 65535),
+				},
+			},
+			"roles": schema.ListAttribute{
+				MarkdownDescription: "节点角色列表（Master, Worker, GPU）。包含 GPU 角色时必须设置 `gpu_product`",
+				Required:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					stringListOneOf(nodeRoles...),
+				},
+				PlanModifiers: []planmodifier.List{
+					requiresReplaceIfRolesChanged(),
+				},
+			},
+			"gpu_product": schema.StringAttribute{
+				MarkdownDescription: "GPU 产品类型（Ascend, Nvidia, Iluvatar, Hygon, Enflame）",
+				Optional:            true,
+				Validators: []validator.String{
+					stringOneOf(gpuProducts...),
+				},
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceIfSet(),
+				},
+			},
+			"password": schema.StringAttribute{
+				MarkdownDescription: "节点 SSH 密码，默认为明文，已加密的密码需配合 `password_encoding = \"encrypted\"` 使用。会保存在 state 中，推荐使用 `password_wo`。与 `password_wo` 二选一",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"password_wo": schema.StringAttribute{
+				MarkdownDescription: "节点 SSH 密码（write-only，需要 Terraform 1.11+），不会写入 plan 和 state。与 `password` 二选一",
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+			},
+			"password_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "`password_wo` 的版本号。修改 `password_wo` 后需同时修改该值",
+				Optional:            true,
+			},
+			"password_encoding": schema.StringAttribute{
+				MarkdownDescription: "密码编码方式：`plain`（明文，默认）或 `encrypted`（已使用 Provider 的 AccessKey Secret 加密）。Provider 提交前总会加密一次密码，`encrypted` 时会先解密，避免重复加密",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(passwordEncodingPlain),
+				Validators: []validator.String{
+					stringOneOf(passwordEncodings...),
+				},
+			},
+			"container_runtime": schema.StringAttribute{
+				MarkdownDescription: "容器运行时（containerd 或 docker），默认为 containerd",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(string(param.ContainerRunTimeContainerd)),
+				Validators: []validator.String{
+					stringOneOf(containerRuntimes...),
+				},
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceIfSet(),
+				},
+			},
+			"dns_server": schema.StringAttribute{
+				MarkdownDescription: "DNS 服务器地址",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceIfSet(),
+				},
+			},
+			"iluvatar_license": schema.StringAttribute{
+				MarkdownDescription: "天数 GPU License。会保存在 state 中，推荐使用 `iluvatar_license_wo`",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"iluvatar_license_wo": schema.StringAttribute{
+				MarkdownDescription: "天数 GPU License（write-only，需要 Terraform 1.11+），不会写入 plan 和 state。与 `iluvatar_license` 二选一",
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+			},
+			"iluvatar_license_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "`iluvatar_license_wo` 的版本号。修改 `iluvatar_license_wo` 后需同时修改该值",
+				Optional:            true,
+			},
+			"image_data_disk": schema.ListAttribute{
+				MarkdownDescription: "镜像数据盘列表，例如 `[\"/dev/sdb\"]`",
+				Optional:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.List{
+					requiresReplaceIfSetList(),
+				},
+			},
+
+			// Computed attributes
+			"status": schema.StringAttribute{
+				MarkdownDescription: "节点状态",
+				Computed:            true,
+			},
+			"role": schema.StringAttribute{
+				MarkdownDescription: "平台返回的节点角色",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cpu": schema.StringAttribute{
+				MarkdownDescription: "CPU 信息",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"memory": schema.StringAttribute{
+				MarkdownDescription: "内存信息",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"storage": schema.StringAttribute{
+				MarkdownDescription: "存储信息",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ClusterNodeResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.ZeClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.ZeClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ClusterNodeResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	validateWriteOnlySecret(ctx, req.Config, "password", "password_wo", true, &resp.Diagnostics)
+	validateWriteOnlySecret(ctx, req.Config, "iluvatar_license", "iluvatar_license_wo", false, &resp.Diagnostics)
+
+	var roles types.List
+	var gpuProduct types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("gpu_product"), &gpuProduct)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("roles"), &roles)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// GPU 角色必须指定 GPU 类型
+	if gpuProduct.IsNull() && slices.Contains(roles.Elements(), attr.Value(types.StringValue(string(param.NodeRoleGPU)))) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("gpu_product"),
+			"Missing GPU product",
+			fmt.Sprintf("The node has the %s role, so gpu_product must be set.", param.NodeRoleGPU),
+		)
+	}
+}
+
+func (r *ClusterNodeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ClusterNodeResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// write-only 属性只存在于配置中
+	password, diags := resolveWriteOnlySecret(ctx, req.Config, data.Password, "password_wo")
+	resp.Diagnostics.Append(diags...)
+	iluvatarLicense, diags := resolveWriteOnlySecret(ctx, req.Config, data.IluvatarLicense, "iluvatar_license_wo")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	password, err := prepareSSHPassword(r.client, password, data.PasswordEncoding.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("password_encoding"), "Invalid SSH password", err.Error())
+		return
+	}
+
+	var roles []string
+	resp.Diagnostics.Append(data.Roles.ElementsAs(ctx, &roles, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nodeRoles := make([]param.ClusterNodeRole, 0, len(roles))
+	for _, role := range roles {
+		nodeRoles = append(nodeRoles, param.ClusterNodeRole(role))
+	}
+
+	clusterID := int(data.ClusterID.ValueInt64())
+	name := data.Name.ValueString()
+
+	addParam := param.NodeAddParamOpenApi{
+		Password: password,
+		NodeAddParam: param.NodeAddParam{
+			ClusterID: data.ClusterID.ValueInt64(),
+			Nodes: []param.NodeAddObjParam{
+				{
+					Name:       name,
+					IP:         data.IP.ValueString(),
+					BusinessIp: data.BusinessIP.ValueString(),
+					IP6:        data.IP6.ValueString(),
+					Port:       int(data.Port.ValueInt64()),
+					Roles:      nodeRoles,
+					GPUProduct: param.GPUProduct(data.GPUProduct.ValueString()),
+				},
+			},
+			ContainerRuntime: param.ContainerRuntime(data.ContainerRuntime.ValueString()),
+			DNSServer:        data.DNSServer.ValueString(),
+			IluvatarLicense:  iluvatarLicense,
+		},
+	}
+
+	if !data.ImageDataDisk.IsNull() {
+		var disks []string
+		resp.Diagnostics.Append(data.ImageDataDisk.ElementsAs(ctx, &disks, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		addParam.ImageDataDisk = map[string][]string{name: disks}
+	}
+
+	unlock, err := lockCluster(ctx, r.client, clusterID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to lock cluster",
+			fmt.Sprintf("Unable to start an operation on cluster %d: %s", clusterID, err),
+		)
+		return
+	}
+	defer unlock()
+
+	tflog.Info(ctx, "Adding node to cluster", map[string]interface{}{
+		"cluster_id": clusterID,
+		"name":       name,
+		"ip":         data.IP.ValueString(),
+	})
+
+	_, err = r.client.AddNode(clusterID, addParam, false)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error adding node",
+			fmt.Sprintf("Unable to add node %q to cluster %d, got error: %s", name, clusterID, err),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(clusterNodeID(clusterID, name))
+
+	node := r.readNode(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if node == nil {
+		resp.Diagnostics.AddError(
+			"Node not found",
+			fmt.Sprintf("Node %q was added to cluster %d but cannot be found in the node list", name, clusterID),
+		)
+		return
+	}
+
+	tflog.Info(ctx, "Node added successfully", map[string]interface{}{
+		"id":     data.ID.ValueString(),
+		"status": data.Status.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ClusterNodeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ClusterNodeResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	node := r.readNode(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// 节点已在平台侧被移除
+	if node == nil {
+		tflog.Warn(ctx, "Node no longer exists, removing from state", map[string]interface{}{
+			"id": data.ID.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ClusterNodeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ClusterNodeResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// 需要重装的属性变更都会触发替换，这里只会是 SSH 端口、密码、License 等仅在添加节点时使用的属性变化，
+	// 或导入后第一次补充的配置
+	r.readNode(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ClusterNodeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ClusterNodeResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterID := int(data.ClusterID.ValueInt64())
+	name := data.Name.ValueString()
+
+	unlock, err := lockCluster(ctx, r.client, clusterID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to lock cluster",
+			fmt.Sprintf("Unable to start an operation on cluster %d: %s", clusterID, err),
+		)
+		return
+	}
+	defer unlock()
+
+	tflog.Info(ctx, "Deleting node from cluster", map[string]interface{}{
+		"cluster_id": clusterID,
+		"name":       name,
+	})
+
+	err = r.client.DeleteNode(clusterID, []string{name})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting node",
+			fmt.Sprintf("Unable to delete node %q from cluster %d, got error: %s", name, clusterID, err),
+		)
+		return
+	}
+
+	tflog.Info(ctx, "Node deleted successfully", map[string]interface{}{
+		"id": data.ID.ValueString(),
+	})
+}
+
+func (r *ClusterNodeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	clusterID, name, err := parseClusterNodeID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing node ID",
+			fmt.Sprintf("Unable to parse node ID '%s': %s. Expected format: <cluster_id>/<name>", req.ID, err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), clusterNodeID(clusterID, name))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cluster_id"), int64(clusterID))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("port"), int64(defaultSSHPort))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("password_encoding"), passwordEncodingPlain)...)
+}
+
+// requiresReplaceIfSet 属性变化时替换节点。导入的节点无法读取这些属性，state 中的旧值为空时
+// 视为未变化，避免导入后的第一次 plan 删除并重新添加节点（与 zstack_node 的 requiresReinstall 一致）
+func requiresReplaceIfSet() planmodifier.String {
+	return stringplanmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+			resp.RequiresReplace = !req.StateValue.IsNull()
+		},
+		"Replaces the node when the value changes, unless the prior value is unset (e.g. after import).",
+		"属性变化时替换节点，旧值为空（如导入后）时除外",
+	)
+}
+
+// requiresReplaceIfSetList 同 requiresReplaceIfSet，用于列表属性
+func requiresReplaceIfSetList() planmodifier.List {
+	return listplanmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.ListRequest, resp *listplanmodifier.RequiresReplaceIfFuncResponse) {
+			resp.RequiresReplace = !req.StateValue.IsNull()
+		},
+		"Replaces the node when the value changes, unless the prior value is unset (e.g. after import).",
+		"属性变化时替换节点，旧值为空（如导入后）时除外",
+	)
+}
+
+// requiresReplaceIfRolesChanged 角色实际变化时替换节点。导入后 roles 来自平台，
+// 顺序和大小写可能与配置不同，只有这类差异时原地更新，不替换节点
+func requiresReplaceIfRolesChanged() planmodifier.List {
+	return listplanmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.ListRequest, resp *listplanmodifier.RequiresReplaceIfFuncResponse) {
+			resp.RequiresReplace = !sameRoles(req.StateValue, req.PlanValue)
+		},
+		"Replaces the node when its roles change, ignoring order and case.",
+		"角色变化时替换节点，忽略顺序和大小写",
+	)
+}
+
+// readNode 通过 PageNode 查询节点并更新计算属性，节点不存在时返回 nil
+func (r *ClusterNodeResource) readNode(ctx context.Context, data *ClusterNodeResourceModel, diags *diag.Diagnostics) *view.NodeView {
+	clusterID := int(data.ClusterID.ValueInt64())
+	name := data.Name.ValueString()
+
+	queryParam := param.NewQueryParam()
+	queryParam.AddQ("name=" + name)
+
+	nodes, _, err := r.client.PageNode(clusterID, queryParam)
+	if isNotFoundError(err) {
+		// 集群已被删除
+		return nil
+	}
+	if err != nil {
+		diags.AddError(
+			"Error reading node",
+			fmt.Sprintf("Unable to read node %q of cluster %d, got error: %s", name, clusterID, err),
+		)
+		return nil
+	}
+
+	var node *view.NodeView
+	for i := range nodes {
+		if nodes[i].Name == name {
+			node = &nodes[i]
+			break
+		}
+	}
+	if node == nil {
+		return nil
+	}
+
+	data.Status = types.StringValue(node.Status)
+	data.Role = types.StringValue(node.Role)
+	data.CPU = types.StringValue(node.CPU)
+	data.Memory = types.StringValue(node.Memory)
+	data.Storage = types.StringValue(node.Storage)
+
+	// 导入时只有 ID，从平台返回的信息补全必填属性
+	if data.IP.IsNull() {
+		data.IP = types.StringValue(node.IP)
+	}
+	if data.Roles.IsNull() {
+		rolesValue, d := types.ListValueFrom(ctx, types.StringType, parseNodeRoles(node.Role))
+		diags.Append(d...)
+		data.Roles = rolesValue
+	}
+
+	tflog.Debug(ctx, "Node details retrieved", map[string]interface{}{
+		"cluster_id": clusterID,
+		"name":       name,
+		"status":     node.Status,
+	})
+
+	return node
+}
+
+// clusterNodeID 生成节点资源 ID：<cluster_id>/<name>
+func clusterNodeID(clusterID int, name string) string {
+	return fmt.Sprintf("%d/%s", clusterID, name)
+}
+
+// parseClusterNodeID 解析 <cluster_id>/<name> 格式的节点资源 ID
+func parseClusterNodeID(id string) (int, string, error) {
+	clusterIDStr, name, ok := strings.Cut(id, "/")
+	if !ok || name == "" {
+		return 0, "", fmt.Errorf("missing node name")
+	}
+
+	clusterID, err := strconv.Atoi(clusterIDStr)
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid cluster ID %q", clusterIDStr)
+	}
+
+	return clusterID, name, nil
+}
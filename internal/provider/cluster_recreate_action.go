@@ -0,0 +1,251 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"zstack.io/edge-go-sdk/pkg/client"
+	"zstack.io/edge-go-sdk/pkg/param"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ action.Action = &ClusterRecreateAction{}
+var _ action.ActionWithConfigure = &ClusterRecreateAction{}
+
+const (
+	// clusterRecreateDefaultTimeout 重装集群的默认超时时间
+	clusterRecreateDefaultTimeout = 90 * time.Minute
+	// clusterRecreatePollInterval 重装过程中查询进度的间隔
+	clusterRecreatePollInterval = 15 * time.Second
+)
+
+func NewClusterRecreateAction() action.Action {
+	return &ClusterRecreateAction{}
+}
+
+// ClusterRecreateAction defines the action implementation.
+type ClusterRecreateAction struct {
+	client *client.ZeClient
+}
+
+// ClusterRecreateActionModel describes the action data model.
+type ClusterRecreateActionModel struct {
+	ClusterID types.Int64  `tfsdk:"cluster_id"`
+	Force     types.Bool   `tfsdk:"force"`
+	Timeout   types.String `tfsdk:"timeout"`
+}
+
+func (a *ClusterRecreateAction) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster_recreate"
+}
+
+func (a *ClusterRecreateAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "重新安装 ZStack Edge 集群（需要 Terraform 1.14+）。用于在不 taint/销毁资源的情况下，主动重装安装失败的集群，并等待重装完成。",
+
+		Attributes: map[string]schema.Attribute{
+			"cluster_id": schema.Int64Attribute{
+				MarkdownDescription: "集群 ID",
+				Required:            true,
+			},
+			"force": schema.BoolAttribute{
+				MarkdownDescription: "是否允许重装非安装失败状态的集群，默认为 `false`",
+				Optional:            true,
+			},
+			"timeout": schema.StringAttribute{
+				MarkdownDescription: "等待重装完成的超时时间，例如 `90m`、`2h`，默认为 `90m`",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (a *ClusterRecreateAction) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.ZeClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected *client.ZeClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	a.client = client
+}
+
+func (a *ClusterRecreateAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var data ClusterRecreateActionModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterID := int(data.ClusterID.ValueInt64())
+
+	timeout := clusterRecreateDefaultTimeout
+	if !data.Timeout.IsNull() {
+		var err error
+		timeout, err = time.ParseDuration(data.Timeout.ValueString())
+		if err != nil || timeout <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("timeout"),
+				"Invalid timeout",
+				fmt.Sprintf("Unable to parse timeout %q as a positive duration such as \"90m\" or \"2h\".", data.Timeout.ValueString()),
+			)
+			return
+		}
+	}
+
+	clusterDetails, err := a.client.GetClusterDetails(clusterID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading cluster",
+			fmt.Sprintf("Unable to read cluster %d, got error: %s", clusterID, err),
+		)
+		return
+	}
+
+	if clusterDetails.Status != clusterStatusCreateFailed && !data.Force.ValueBool() {
+		resp.Diagnostics.AddError(
+			"Cluster is not in a failed state",
+			fmt.Sprintf("Cluster %d (%s) has status %q. Only clusters with status %q are recreated by default; "+
+				"set force = true to reinstall it anyway.",
+				clusterID, clusterDetails.Name, clusterDetails.Status, clusterStatusCreateFailed),
+		)
+		return
+	}
+
+	tflog.Info(ctx, "Recreating cluster", map[string]interface{}{
+		"id":      clusterID,
+		"status":  clusterDetails.Status,
+		"timeout": timeout.String(),
+	})
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Recreating cluster %d (%s), current status: %s", clusterID, clusterDetails.Name, clusterDetails.Status),
+	})
+
+	unlock, err := lockCluster(ctx, a.client, clusterID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to lock cluster",
+			fmt.Sprintf("Unable to start an operation on cluster %d: %s", clusterID, err),
+		)
+		return
+	}
+
+	// SDK 同步调用会一直阻塞到重装结束，放到后台执行，前台定期上报进度。
+	// 超时返回后重装仍在进行，因此由后台任务在结束时释放集群锁。
+	done := make(chan error, 1)
+	go func() {
+		defer unlock()
+		_, err := a.client.RecreateCluster(clusterID, false)
+		done <- err
+	}()
+
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	ticker := time.NewTicker(clusterRecreatePollInterval)
+	defer ticker.Stop()
+
+	lastProgress := ""
+	for {
+		select {
+		case err := <-done:
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error recreating cluster",
+					fmt.Sprintf("Unable to recreate cluster %d, got error: %s", clusterID, err),
+				)
+				return
+			}
+
+			clusterDetails, err := a.client.GetClusterDetails(clusterID)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error reading cluster",
+					fmt.Sprintf("Unable to read cluster %d after recreation, got error: %s", clusterID, err),
+				)
+				return
+			}
+			if clusterDetails.Status == clusterStatusCreateFailed {
+				resp.Diagnostics.AddError(
+					"Cluster recreation failed",
+					fmt.Sprintf("Cluster %d (%s) is still in status %q after recreation. Check the cluster operation log for details.",
+						clusterID, clusterDetails.Name, clusterDetails.Status),
+				)
+				return
+			}
+
+			resp.SendProgress(action.InvokeProgressEvent{
+				Message: fmt.Sprintf("Cluster %d (%s) recreated, status: %s", clusterID, clusterDetails.Name, clusterDetails.Status),
+			})
+			tflog.Info(ctx, "Cluster recreated successfully", map[string]interface{}{
+				"id":     clusterID,
+				"status": clusterDetails.Status,
+			})
+			return
+
+		case <-ticker.C:
+			progress := a.clusterProgress(clusterID)
+			if progress != "" && progress != lastProgress {
+				lastProgress = progress
+				resp.SendProgress(action.InvokeProgressEvent{Message: progress})
+			}
+
+		case <-deadline.C:
+			resp.Diagnostics.AddError(
+				"Timeout waiting for cluster recreation",
+				fmt.Sprintf("Cluster %d was not recreated within %s. The recreation may still be running on the server; "+
+					"check the cluster status before invoking the action again.", clusterID, timeout),
+			)
+			return
+
+		case <-ctx.Done():
+			resp.Diagnostics.AddError(
+				"Cluster recreation interrupted",
+				fmt.Sprintf("Stopped waiting for cluster %d: %s. The recreation may still be running on the server.", clusterID, ctx.Err()),
+			)
+			return
+		}
+	}
+}
+
+// clusterProgress 返回集群当前状态及最近一次操作的描述，查询失败时返回空字符串
+func (a *ClusterRecreateAction) clusterProgress(clusterID int) string {
+	clusterDetails, err := a.client.GetClusterDetails(clusterID)
+	if err != nil {
+		return ""
+	}
+
+	progress := fmt.Sprintf("Cluster %d status: %s", clusterID, clusterDetails.Status)
+
+	// 按创建时间倒序，只取最近一次操作
+	queryParam := param.NewQueryParam()
+	sortQuery(&queryParam, "createTime", true)
+	queryParam.Limit(1)
+	operations, _, err := a.client.PageClusterOperation(clusterID, queryParam)
+	if err == nil && len(operations) > 0 {
+		operation := operations[0]
+		progress += fmt.Sprintf(", operation %s: %s", operation.Operation, operation.Status)
+		if operation.Message != "" {
+			progress += fmt.Sprintf(" (%s)", operation.Message)
+		}
+	}
+
+	return progress
+}
@@ -6,6 +6,8 @@ package provider
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -13,7 +15,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
@@ -24,6 +28,14 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &ClusterResource{}
 var _ resource.ResourceWithImportState = &ClusterResource{}
+var _ resource.ResourceWithValidateConfig = &ClusterResource{}
+var _ resource.ResourceWithModifyPlan = &ClusterResource{}
+
+// clusterStatusCreateFailed 集群安装失败状态
+const clusterStatusCreateFailed = "Status_Cluster_Create_Failed"
+
+// clusterReplaceAttributes 修改后会导致集群被替换（删除后重建）的属性
+var clusterReplaceAttributes = []string{"management_vip_v4", "business_vip_v4", "pod_cidr_v4", "service_cidr_v4"}
 
 func NewClusterResource() resource.Resource {
 	return &ClusterResource{}
@@ -42,6 +54,9 @@ type ClusterResourceModel struct {
 	NetCombined      types.Bool   `tfsdk:"net_combined"`
 	Port             types.Int64  `tfsdk:"port"`
 	Password         types.String `tfsdk:"password"`
+	PasswordWO       types.String `tfsdk:"password_wo"`
+	PasswordWOVer    types.Int64  `tfsdk:"password_wo_version"`
+	PasswordEncoding types.String `tfsdk:"password_encoding"`
 	ManagementVipV4  types.String `tfsdk:"management_vip_v4"`
 	BusinessVipV4    types.String `tfsdk:"business_vip_v4"`
 	MaxPodPerNode    types.Int64  `tfsdk:"max_pod_per_node"`
@@ -52,10 +67,15 @@ type ClusterResourceModel struct {
 	K8sVersion       types.String `tfsdk:"k8s_version"`
 	IluvatarGpuModel types.String `tfsdk:"iluvatar_gpu_model"`
 	IluvatarLicense  types.String `tfsdk:"iluvatar_license"`
+	IluvatarLicWO    types.String `tfsdk:"iluvatar_license_wo"`
+	IluvatarLicWOVer types.Int64  `tfsdk:"iluvatar_license_wo_version"`
 	Nodes            types.List   `tfsdk:"nodes"` // []ClusterNodeModel
 	DataDisk         types.Map    `tfsdk:"data_disk"`
 	ImageDataDisk    types.Map    `tfsdk:"image_data_disk"`
 
+	DeletionProtection types.Bool `tfsdk:"deletion_protection"`
+	Preflight          types.Bool `tfsdk:"preflight"`
+
 	// Computed fields
 	Status        types.String `tfsdk:"status"`
 	Version       types.String `tfsdk:"version"`
@@ -103,9 +123,28 @@ func (r *ClusterResource) Schema(ctx context.Context, req resource.SchemaRequest
 				Required:            true,
 			},
 			"password": schema.StringAttribute{
-				MarkdownDescription: "SSH 密码（加密后的）",
-				Required:            true,
+				MarkdownDescription: "SSH 密码，默认为明文，已加密的密码需配合 `password_encoding = \"encrypted\"` 使用。会保存在 state 中，推荐使用 `password_wo`。与 `password_wo` 二选一",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"password_wo": schema.StringAttribute{
+				MarkdownDescription: "SSH 密码（write-only，需要 Terraform 1.11+），不会写入 plan 和 state。与 `password` 二选一",
+				Optional:            true,
 				Sensitive:           true,
+				WriteOnly:           true,
+			},
+			"password_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "`password_wo` 的版本号。修改 `password_wo` 后需同时修改该值，新密码才会在后续操作中生效",
+				Optional:            true,
+			},
+			"password_encoding": schema.StringAttribute{
+				MarkdownDescription: "密码编码方式：`plain`（明文，默认）或 `encrypted`（已使用 Provider 的 AccessKey Secret 加密）。Provider 提交前总会加密一次密码，`encrypted` 时会先解密，避免重复加密",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(passwordEncodingPlain),
+				Validators: []validator.String{
+					stringOneOf(passwordEncodings...),
+				},
 			},
 			"management_vip_v4": schema.StringAttribute{
 				MarkdownDescription: "管理网络 VIP IPv4 地址",
@@ -156,9 +195,19 @@ func (r *ClusterResource) Schema(ctx context.Context, req resource.SchemaRequest
 				Optional:            true,
 			},
 			"iluvatar_license": schema.StringAttribute{
-				MarkdownDescription: "天数 GPU 许可证",
+				MarkdownDescription: "天数 GPU 许可证。会保存在 state 中，推荐使用 `iluvatar_license_wo`",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"iluvatar_license_wo": schema.StringAttribute{
+				MarkdownDescription: "天数 GPU 许可证（write-only，需要 Terraform 1.11+），不会写入 plan 和 state。与 `iluvatar_license` 二选一",
 				Optional:            true,
 				Sensitive:           true,
+				WriteOnly:           true,
+			},
+			"iluvatar_license_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "`iluvatar_license_wo` 的版本号。修改 `iluvatar_license_wo` 后需同时修改该值，新许可证才会在后续操作中生效",
+				Optional:            true,
 			},
 			"nodes": schema.ListNestedAttribute{
 				MarkdownDescription: "集群节点列表",
@@ -199,6 +248,18 @@ func (r *ClusterResource) Schema(ctx context.Context, req resource.SchemaRequest
 				Optional:            true,
 				ElementType:         types.ListType{ElemType: types.StringType},
 			},
+			"deletion_protection": schema.BoolAttribute{
+				MarkdownDescription: "删除保护。开启时拒绝删除集群（包括因修改 CIDR/VIP 导致的替换）。" +
+					"如需删除，必须先将其设置为 `false` 并 apply，然后再执行 destroy。" +
+					"未配置时新建的集群默认开启；在引入该属性之前创建的集群保持关闭，不会被自动开启",
+				Optional: true,
+				Computed: true,
+			},
+			"preflight": schema.BoolAttribute{
+				MarkdownDescription: "是否在创建集群前执行预检，默认为 `false`。开启后会在 plan（以及 apply 开始）时通过 SSH 登录每个节点，" +
+					"检查 SSH 凭据、`data_disk` / `image_data_disk` 中的磁盘是否存在且未被使用，以及天数 GPU 节点是否提供了 License，并一次性报告所有问题",
+				Optional: true,
+			},
 
 			// Computed attributes
 			"status": schema.StringAttribute{
@@ -242,6 +303,160 @@ func (r *ClusterResource) Configure(ctx context.Context, req resource.ConfigureR
 	r.client = client
 }
 
+func (r *ClusterResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	validateWriteOnlySecret(ctx, req.Config, "password", "password_wo", true, &resp.Diagnostics)
+	validateWriteOnlySecret(ctx, req.Config, "iluvatar_license", "iluvatar_license_wo", false, &resp.Diagnostics)
+}
+
+func (r *ClusterResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// 新建集群只需执行预检
+	if req.State.Raw.IsNull() {
+		r.planDeletionProtection(ctx, req, resp, types.BoolValue(true))
+		r.preflight(ctx, req, resp)
+		return
+	}
+
+	var state ClusterResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// 已有集群未配置删除保护时沿用 state 中的值，旧版本创建的集群（state 中为 null）保持关闭
+	r.planDeletionProtection(ctx, req, resp, state.DeletionProtection)
+	if resp.Diagnostics.HasError() || !state.DeletionProtection.ValueBool() {
+		return
+	}
+
+	if req.Plan.Raw.IsNull() {
+		resp.Diagnostics.AddWarning(
+			"Cluster is protected from deletion",
+			fmt.Sprintf("Cluster %d (%s) has deletion_protection enabled, so destroying it will fail. "+
+				"Set deletion_protection = false and run terraform apply first.",
+				state.ID.ValueInt64(), state.Name.ValueString()),
+		)
+		return
+	}
+
+	var changed []string
+	for _, attr := range clusterReplaceAttributes {
+		var planValue, stateValue types.String
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(attr), &planValue)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(attr), &stateValue)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if !planValue.Equal(stateValue) {
+			changed = append(changed, attr)
+		}
+	}
+
+	if len(changed) > 0 {
+		resp.Diagnostics.AddWarning(
+			"Cluster replacement blocked by deletion protection",
+			fmt.Sprintf("Changing %v forces cluster %d (%s) to be replaced, but deletion_protection is enabled, "+
+				"so deleting the existing cluster will fail. Revert the change, or set deletion_protection = false "+
+				"and run terraform apply first if the cluster really should be recreated.",
+				changed, state.ID.ValueInt64(), state.Name.ValueString()),
+		)
+	}
+}
+
+// planDeletionProtection 在未配置 deletion_protection 时将计划值设为 value。
+// 默认值只对新建集群生效，因此不使用 schema 的 Default
+func (r *ClusterResource) planDeletionProtection(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, value types.Bool) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var configured types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("deletion_protection"), &configured)...)
+	if resp.Diagnostics.HasError() || !configured.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("deletion_protection"), value)...)
+}
+
+// preflight 在创建集群前检查 SSH 凭据、数据盘和天数 GPU License。
+// 配置中存在未知值时跳过，apply 时 Terraform 会用已知值再次 plan，届时执行检查。
+func (r *ClusterResource) preflight(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var data ClusterResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || !data.Preflight.ValueBool() {
+		return
+	}
+
+	if !req.Config.Raw.IsFullyKnown() {
+		tflog.Debug(ctx, "Configuration contains unknown values, deferring cluster preflight checks to apply")
+		return
+	}
+
+	password, diags := resolveWriteOnlySecret(ctx, req.Config, data.Password, "password_wo")
+	resp.Diagnostics.Append(diags...)
+	iluvatarLicense, diags := resolveWriteOnlySecret(ctx, req.Config, data.IluvatarLicense, "iluvatar_license_wo")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	password, err := prepareSSHPassword(r.client, password, data.PasswordEncoding.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("password_encoding"), "Invalid SSH password", err.Error())
+		return
+	}
+
+	var nodes []ClusterNodeModel
+	var dataDisk, imageDataDisk map[string][]string
+	resp.Diagnostics.Append(data.Nodes.ElementsAs(ctx, &nodes, false)...)
+	resp.Diagnostics.Append(data.DataDisk.ElementsAs(ctx, &dataDisk, false)...)
+	if !data.ImageDataDisk.IsNull() {
+		resp.Diagnostics.Append(data.ImageDataDisk.ElementsAs(ctx, &imageDataDisk, false)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	checker := newPreflightChecker(r.client)
+
+	nodeNames := make(map[string]bool, len(nodes))
+	hosts := make([]preflightHost, 0, len(nodes))
+	var iluvatarNodes []string
+	for _, node := range nodes {
+		name := node.Name.ValueString()
+		nodeNames[name] = true
+		hosts = append(hosts, preflightHost{
+			name:  name,
+			ip:    node.ManagementIPv4Addr.ValueString(),
+			port:  int(data.Port.ValueInt64()),
+			disks: append(append([]string{}, dataDisk[name]...), imageDataDisk[name]...),
+		})
+		if node.GPUProduct.ValueString() == string(param.GPUProductIluvatar) {
+			iluvatarNodes = append(iluvatarNodes, name)
+		}
+	}
+
+	for _, disks := range []map[string][]string{dataDisk, imageDataDisk} {
+		for _, name := range slices.Sorted(maps.Keys(disks)) {
+			if !nodeNames[name] {
+				checker.addFinding("disks are configured for node %q, which is not in nodes", name)
+			}
+		}
+	}
+
+	// 新集群还没有 License，天数 GPU 节点必须在配置中提供
+	if len(iluvatarNodes) > 0 && iluvatarLicense == "" {
+		checker.addFinding("Iluvatar GPU nodes %v require iluvatar_license_wo or iluvatar_license", iluvatarNodes)
+	}
+
+	checker.checkHosts(ctx, password, hosts)
+	checker.report(&resp.Diagnostics)
+}
+
 func (r *ClusterResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data ClusterResourceModel
 
@@ -254,13 +469,28 @@ func (r *ClusterResource) Create(ctx context.Context, req resource.CreateRequest
 		"name": data.Name.ValueString(),
 	})
 
+	// write-only 属性只存在于配置中
+	password, diags := resolveWriteOnlySecret(ctx, req.Config, data.Password, "password_wo")
+	resp.Diagnostics.Append(diags...)
+	iluvatarLicense, diags := resolveWriteOnlySecret(ctx, req.Config, data.IluvatarLicense, "iluvatar_license_wo")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	password, err := prepareSSHPassword(r.client, password, data.PasswordEncoding.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("password_encoding"), "Invalid SSH password", err.Error())
+		return
+	}
+
 	// Build cluster create parameters
 	createParam := param.ClusterCreateParam{
 		Name:            data.Name.ValueString(),
 		EnableHA:        !data.EnableHA.IsNull() && data.EnableHA.ValueBool(),
 		NetCombined:     !data.NetCombined.IsNull() && data.NetCombined.ValueBool(),
 		Port:            int(data.Port.ValueInt64()),
-		Password:        data.Password.ValueString(),
+		Password:        password,
 		ManagementVipV4: data.ManagementVipV4.ValueString(),
 		BusinessVipV4:   data.BusinessVipV4.ValueString(),
 		PodCidrV4:       data.PodCidrV4.ValueString(),
@@ -281,8 +511,8 @@ func (r *ClusterResource) Create(ctx context.Context, req resource.CreateRequest
 		createParam.IluvatarGpuModel = data.IluvatarGpuModel.ValueString()
 	}
 
-	if !data.IluvatarLicense.IsNull() {
-		createParam.IluvatarLicense = data.IluvatarLicense.ValueString()
+	if iluvatarLicense != "" {
+		createParam.IluvatarLicense = iluvatarLicense
 	}
 
 	// Parse nodes
@@ -350,8 +580,19 @@ func (r *ClusterResource) Create(ctx context.Context, req resource.CreateRequest
 	}
 	taskID := ""
 	if len(clusters) > 0 {
-		if clusters[0].Status == "Status_Cluster_Create_Failed" {
-			taskID, err = r.client.RecreateCluster(int(clusters[0].ID), false)
+		if clusters[0].Status == clusterStatusCreateFailed {
+			clusterID := int(clusters[0].ID)
+			unlock, err := lockCluster(ctx, r.client, clusterID)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Failed to lock cluster",
+					fmt.Sprintf("Unable to start an operation on cluster %d: %s", clusterID, err),
+				)
+				return
+			}
+			defer unlock()
+
+			taskID, err = r.client.RecreateCluster(clusterID, false)
 			if err != nil {
 				resp.Diagnostics.AddError("Error recreate cluster",
 					fmt.Sprintf("Unable to create cluster, got error: %s", err))
@@ -426,6 +667,11 @@ func (r *ClusterResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
+	// 引入 deletion_protection 之前创建的集群 state 中为 null，补为关闭，避免升级 Provider 后出现 null -> false 的变更
+	if data.DeletionProtection.IsNull() {
+		data.DeletionProtection = types.BoolValue(false)
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -458,11 +704,32 @@ func (r *ClusterResource) Delete(ctx context.Context, req resource.DeleteRequest
 
 	clusterID := int(data.ID.ValueInt64())
 
+	// 删除保护以 state 中的值为准，关闭保护必须先 apply
+	if data.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddError(
+			"Cluster is protected from deletion",
+			fmt.Sprintf("Cluster %d (%s) has deletion_protection enabled. "+
+				"Set deletion_protection = false and run terraform apply first, then destroy or replace the cluster.",
+				clusterID, data.Name.ValueString()),
+		)
+		return
+	}
+
+	unlock, err := lockCluster(ctx, r.client, clusterID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to lock cluster",
+			fmt.Sprintf("Unable to start an operation on cluster %d: %s", clusterID, err),
+		)
+		return
+	}
+	defer unlock()
+
 	tflog.Info(ctx, "Deleting cluster", map[string]interface{}{
 		"id": clusterID,
 	})
 
-	_, err := r.client.DeleteCluster(clusterID, false)
+	_, err = r.client.DeleteCluster(clusterID, false)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error deleting cluster",
@@ -487,6 +754,7 @@ func (r *ClusterResource) ImportState(ctx context.Context, req resource.ImportSt
 	}
 
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), clusterID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("deletion_protection"), true)...)
 }
 
 // Helper function to read cluster details
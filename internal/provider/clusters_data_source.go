@@ -4,18 +4,46 @@
 package provider
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"zstack.io/edge-go-sdk/pkg/client"
 	"zstack.io/edge-go-sdk/pkg/param"
+	"zstack.io/edge-go-sdk/pkg/view"
 )
 
+// clusterSortFields sort_by 可选的字段及对应的平台排序字段，node_count 只能在本地排序
+var clusterSortFields = map[string]string{
+	"id":          "id",
+	"name":        "name",
+	"create_time": "createTime",
+	"node_count":  "",
+}
+
+// clusterFilterFields filter 块可以使用的字段，平台只支持按 ID 和名称查询
+var clusterFilterFields = map[string]queryFilterField[view.ClusterView]{
+	"id":          {query: "id", value: func(c view.ClusterView) string { return strconv.FormatInt(c.ID, 10) }},
+	"name":        {query: "name", value: func(c view.ClusterView) string { return c.Name }},
+	"status":      {value: func(c view.ClusterView) string { return c.Status }},
+	"version":     {value: func(c view.ClusterView) string { return c.Version }},
+	"create_type": {value: func(c view.ClusterView) string { return string(c.CreateType) }},
+	"node_count":  {value: func(c view.ClusterView) string { return strconv.Itoa(c.NodeCount) }},
+	"create_time": {value: func(c view.ClusterView) string { return c.CreateTime.String() }},
+}
+
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ datasource.DataSource = &ClustersDataSource{}
 
@@ -30,10 +58,21 @@ type ClustersDataSource struct {
 
 // ClustersDataSourceModel describes the data source data model.
 type ClustersDataSourceModel struct {
-	Clusters []ClusterListItemModel `tfsdk:"clusters"`
-	Limit    types.Int64            `tfsdk:"limit"`
-	Offset   types.Int64            `tfsdk:"offset"`
-	Total    types.Int64            `tfsdk:"total"`
+	Clusters   []ClusterListItemModel          `tfsdk:"clusters"`
+	Limit      types.Int64                     `tfsdk:"limit"`
+	Offset     types.Int64                     `tfsdk:"offset"`
+	Total      types.Int64                     `tfsdk:"total"`
+	Name       types.String                    `tfsdk:"name"`
+	NamePrefix types.String                    `tfsdk:"name_prefix"`
+	NameRegex  types.String                    `tfsdk:"name_regex"`
+	Status     types.String                    `tfsdk:"status"`
+	CreateType types.String                    `tfsdk:"create_type"`
+	Version    types.String                    `tfsdk:"version"`
+	SortBy     types.String                    `tfsdk:"sort_by"`
+	SortOrder  types.String                    `tfsdk:"sort_order"`
+	IDs        []types.Int64                   `tfsdk:"ids"`
+	ByName     map[string]ClusterListItemModel `tfsdk:"by_name"`
+	Filter     []QueryFilterModel              `tfsdk:"filter"`
 }
 
 // ClusterListItemModel describes a cluster item in the list.
@@ -57,73 +96,142 @@ func (d *ClustersDataSource) Metadata(ctx context.Context, req datasource.Metada
 
 func (d *ClustersDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "获取 ZStack Edge 集群列表的数据源。支持分页查询。",
+		MarkdownDescription: "获取 ZStack Edge 集群列表的数据源。默认自动分页查询全部集群，支持按名称、状态、创建类型和版本过滤及排序。",
 
 		Attributes: map[string]schema.Attribute{
 			"limit": schema.Int64Attribute{
-				MarkdownDescription: "每页返回的记录数，默认为 20",
+				MarkdownDescription: "只查询一页时每页返回的记录数。未设置时自动分页查询全部集群",
 				Optional:            true,
+				Validators: []validator.Int64{
+					int64Between(1, 1000),
+				},
 			},
 			"offset": schema.Int64Attribute{
-				MarkdownDescription: "偏移量，用于分页，默认为 0",
+				MarkdownDescription: "偏移量，默认为 0",
 				Optional:            true,
+				Validators: []validator.Int64{
+					int64Between(0, 1<<31-1),
+				},
 			},
 			"total": schema.Int64Attribute{
-				MarkdownDescription: "集群总数",
-				Computed:            true,
+				MarkdownDescription: "平台中满足服务端查询条件的集群总数：包括 `name` 以及 `filter` 中按 `id`、`name` 过滤的条件，" +
+					"不受其他在本地执行的过滤条件和 `limit` 影响",
+				Computed: true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "按集群名称精确过滤",
+				Optional:            true,
+			},
+			"name_prefix": schema.StringAttribute{
+				MarkdownDescription: "按集群名称前缀过滤",
+				Optional:            true,
+			},
+			"name_regex": schema.StringAttribute{
+				MarkdownDescription: "按正则表达式（RE2 语法）过滤集群名称",
+				Optional:            true,
+				Validators: []validator.String{
+					validRegexp(),
+				},
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "按集群状态过滤，如 `Running`",
+				Optional:            true,
+			},
+			"create_type": schema.StringAttribute{
+				MarkdownDescription: "按集群创建类型过滤：`Inner` 或 `Outer`",
+				Optional:            true,
+			},
+			"version": schema.StringAttribute{
+				MarkdownDescription: "按集群版本过滤",
+				Optional:            true,
+			},
+			"sort_by": schema.StringAttribute{
+				MarkdownDescription: "排序字段：`id`、`name`、`create_time` 或 `node_count`。未设置时保持平台返回的顺序",
+				Optional:            true,
+				Validators: []validator.String{
+					stringOneOf(slices.Sorted(maps.Keys(clusterSortFields))...),
+				},
+			},
+			"sort_order": schema.StringAttribute{
+				MarkdownDescription: "排序方向：`asc`（默认）或 `desc`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringOneOf("asc", "desc"),
+				},
 			},
 			"clusters": schema.ListNestedAttribute{
 				MarkdownDescription: "集群列表",
 				Computed:            true,
 				NestedObject: schema.NestedAttributeObject{
-					Attributes: map[string]schema.Attribute{
-						"id": schema.Int64Attribute{
-							MarkdownDescription: "集群唯一标识符",
-							Computed:            true,
-						},
-						"name": schema.StringAttribute{
-							MarkdownDescription: "集群名称",
-							Computed:            true,
-						},
-						"status": schema.StringAttribute{
-							MarkdownDescription: "集群状态",
-							Computed:            true,
-						},
-						"version": schema.StringAttribute{
-							MarkdownDescription: "集群版本",
-							Computed:            true,
-						},
-						"node_count": schema.Int64Attribute{
-							MarkdownDescription: "集群节点数量",
-							Computed:            true,
-						},
-						"create_time": schema.StringAttribute{
-							MarkdownDescription: "集群创建时间",
-							Computed:            true,
-						},
-						"prometheus_url": schema.StringAttribute{
-							MarkdownDescription: "Prometheus 监控地址",
-							Computed:            true,
-						},
-						"create_type": schema.StringAttribute{
-							MarkdownDescription: "集群创建类型",
-							Computed:            true,
-						},
-						"cpu_usage": schema.StringAttribute{
-							MarkdownDescription: "CPU 使用情况",
-							Computed:            true,
-						},
-						"memory_usage": schema.StringAttribute{
-							MarkdownDescription: "内存使用情况",
-							Computed:            true,
-						},
-						"storage_usage": schema.StringAttribute{
-							MarkdownDescription: "存储使用情况",
-							Computed:            true,
-						},
-					},
+					Attributes: clusterListItemAttributes(),
 				},
 			},
+			"ids": schema.ListAttribute{
+				MarkdownDescription: "集群 ID 列表，顺序与 `clusters` 相同",
+				Computed:            true,
+				ElementType:         types.Int64Type,
+			},
+			"by_name": schema.MapNestedAttribute{
+				MarkdownDescription: "以集群名称为键的集群信息，名称重复时保留排在后面的集群",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: clusterListItemAttributes(),
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"filter": queryFilterBlock(clusterFilterFields),
+		},
+	}
+}
+
+// clusterListItemAttributes 返回 clusters 和 by_name 中单个集群的属性
+func clusterListItemAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.Int64Attribute{
+			MarkdownDescription: "集群唯一标识符",
+			Computed:            true,
+		},
+		"name": schema.StringAttribute{
+			MarkdownDescription: "集群名称",
+			Computed:            true,
+		},
+		"status": schema.StringAttribute{
+			MarkdownDescription: "集群状态",
+			Computed:            true,
+		},
+		"version": schema.StringAttribute{
+			MarkdownDescription: "集群版本",
+			Computed:            true,
+		},
+		"node_count": schema.Int64Attribute{
+			MarkdownDescription: "集群节点数量",
+			Computed:            true,
+		},
+		"create_time": schema.StringAttribute{
+			MarkdownDescription: "集群创建时间",
+			Computed:            true,
+		},
+		"prometheus_url": schema.StringAttribute{
+			MarkdownDescription: "Prometheus 监控地址",
+			Computed:            true,
+		},
+		"create_type": schema.StringAttribute{
+			MarkdownDescription: "集群创建类型",
+			Computed:            true,
+		},
+		"cpu_usage": schema.StringAttribute{
+			MarkdownDescription: "CPU 使用情况",
+			Computed:            true,
+		},
+		"memory_usage": schema.StringAttribute{
+			MarkdownDescription: "内存使用情况",
+			Computed:            true,
+		},
+		"storage_usage": schema.StringAttribute{
+			MarkdownDescription: "存储使用情况",
+			Computed:            true,
 		},
 	}
 }
@@ -153,28 +261,39 @@ func (d *ClustersDataSource) Read(ctx context.Context, req datasource.ReadReques
 		return
 	}
 
-	// Set default values
-	limit := 20
 	offset := 0
-
-	if !data.Limit.IsNull() {
-		limit = int(data.Limit.ValueInt64())
-	}
-
 	if !data.Offset.IsNull() {
 		offset = int(data.Offset.ValueInt64())
 	}
 
 	tflog.Info(ctx, "Reading clusters list", map[string]interface{}{
-		"limit":  limit,
+		"limit":  data.Limit.ValueInt64(),
 		"offset": offset,
 	})
 
-	// Query clusters
+	// 平台支持按名称精确查询和排序，其余条件在本地过滤
 	queryParam := param.NewQueryParam()
-	queryParam.Limit(limit).Start(offset)
+	if !data.Name.IsNull() {
+		queryParam.AddQ("name=" + data.Name.ValueString())
+	}
+	desc := data.SortOrder.ValueString() == "desc"
+	if field := clusterSortFields[data.SortBy.ValueString()]; field != "" {
+		sortQuery(&queryParam, field, desc)
+	}
+	match, err := applyQueryFilters(data.Filter, clusterFilterFields, &queryParam)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("filter"), "Invalid filter", err.Error())
+		return
+	}
 
-	clusters, total, err := d.client.PageCluster(queryParam)
+	var clusters []view.ClusterView
+	var total int
+	if !data.Limit.IsNull() {
+		queryParam.Limit(int(data.Limit.ValueInt64())).Start(offset)
+		clusters, total, err = d.client.PageCluster(queryParam)
+	} else {
+		clusters, total, err = pageAll(queryParam, offset, d.client.PageCluster)
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading clusters",
@@ -183,9 +302,21 @@ func (d *ClustersDataSource) Read(ctx context.Context, req datasource.ReadReques
 		return
 	}
 
+	clusters, err = filterClusters(clusters, &data)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid name_regex", err.Error())
+		return
+	}
+	clusters = slices.DeleteFunc(clusters, func(cluster view.ClusterView) bool { return !match(cluster) })
+	if !data.SortBy.IsNull() {
+		sortClusters(clusters, data.SortBy.ValueString(), desc)
+	}
+
 	// Map response to data source model
 	data.Total = types.Int64Value(int64(total))
 	data.Clusters = make([]ClusterListItemModel, len(clusters))
+	data.IDs = make([]types.Int64, len(clusters))
+	data.ByName = make(map[string]ClusterListItemModel, len(clusters))
 
 	for i, cluster := range clusters {
 		data.Clusters[i] = ClusterListItemModel{
@@ -201,6 +332,8 @@ func (d *ClustersDataSource) Read(ctx context.Context, req datasource.ReadReques
 			MemoryUsage:   types.StringValue(cluster.Memory),
 			StorageUsage:  types.StringValue(cluster.Storage),
 		}
+		data.IDs[i] = types.Int64Value(cluster.ID)
+		data.ByName[cluster.Name] = data.Clusters[i]
 	}
 
 	tflog.Debug(ctx, "Clusters list retrieved successfully", map[string]interface{}{
@@ -210,3 +343,51 @@ func (d *ClustersDataSource) Read(ctx context.Context, req datasource.ReadReques
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// filterClusters 按名称前缀、正则、状态、创建类型和版本过滤集群
+func filterClusters(clusters []view.ClusterView, data *ClustersDataSourceModel) ([]view.ClusterView, error) {
+	var nameRegex *regexp.Regexp
+	if !data.NameRegex.IsNull() {
+		re, err := regexp.Compile(data.NameRegex.ValueString())
+		if err != nil {
+			return nil, fmt.Errorf("unable to compile name_regex %q: %w", data.NameRegex.ValueString(), err)
+		}
+		nameRegex = re
+	}
+
+	return slices.DeleteFunc(clusters, func(cluster view.ClusterView) bool {
+		switch {
+		case !data.Name.IsNull() && cluster.Name != data.Name.ValueString():
+		case !data.NamePrefix.IsNull() && !strings.HasPrefix(cluster.Name, data.NamePrefix.ValueString()):
+		case nameRegex != nil && !nameRegex.MatchString(cluster.Name):
+		case !data.Status.IsNull() && cluster.Status != data.Status.ValueString():
+		case !data.CreateType.IsNull() && string(cluster.CreateType) != data.CreateType.ValueString():
+		case !data.Version.IsNull() && cluster.Version != data.Version.ValueString():
+		default:
+			return false
+		}
+		return true
+	}), nil
+}
+
+// sortClusters 按 sort_by 字段排序，值相同时按 ID 排序
+func sortClusters(clusters []view.ClusterView, sortBy string, desc bool) {
+	slices.SortStableFunc(clusters, func(a, b view.ClusterView) int {
+		var c int
+		switch sortBy {
+		case "name":
+			c = strings.Compare(a.Name, b.Name)
+		case "create_time":
+			c = a.CreateTime.Compare(b.CreateTime)
+		case "node_count":
+			c = cmp.Compare(a.NodeCount, b.NodeCount)
+		}
+		if c == 0 {
+			c = cmp.Compare(a.ID, b.ID)
+		}
+		if desc {
+			return -c
+		}
+		return c
+	})
+}
@@ -0,0 +1,219 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"zstack.io/edge-go-sdk/pkg/client"
+	"zstack.io/edge-go-sdk/pkg/view"
+)
+
+var _ datasource.DataSource = &ExternalNetworkInterfacesDataSource{}
+
+func NewExternalNetworkInterfacesDataSource() datasource.DataSource {
+	return &ExternalNetworkInterfacesDataSource{}
+}
+
+type ExternalNetworkInterfacesDataSource struct {
+	client *client.ZeClient
+}
+
+type ExternalNetworkInterfacesDataSourceModel struct {
+	ClusterID        types.Int64                      `tfsdk:"cluster_id"`
+	Refresh          types.Bool                       `tfsdk:"refresh"`
+	SameIfaces       []string                         `tfsdk:"same_ifaces"`
+	MasterSameIfaces []string                         `tfsdk:"master_same_ifaces"`
+	Nodes            []ExternalNetworkNodeIfacesModel `tfsdk:"nodes"`
+}
+
+type ExternalNetworkNodeIfacesModel struct {
+	Host          types.String                              `tfsdk:"host"`
+	Name          types.String                              `tfsdk:"name"`
+	IsMaster      types.Bool                                `tfsdk:"is_master"`
+	Ifaces        []string                                  `tfsdk:"ifaces"`
+	IfaceWithVlan []string                                  `tfsdk:"iface_with_vlan"`
+	VlanMap       map[string]string                         `tfsdk:"vlan_map"`
+	BridgeMap     map[string]string                         `tfsdk:"bridge_map"`
+	Routes        map[string]ExternalNetworkIfaceRouteModel `tfsdk:"routes"`
+}
+
+type ExternalNetworkIfaceRouteModel struct {
+	Interface types.String `tfsdk:"interface"`
+	IpRange   types.String `tfsdk:"ip_range"`
+}
+
+func (d *ExternalNetworkInterfacesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_external_network_interfaces"
+}
+
+func (d *ExternalNetworkInterfacesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "查询集群中可用于外部网络的网卡。`zstack_external_network` 的 `interface` 必须是 `same_ifaces` 中的网卡。",
+
+		Attributes: map[string]schema.Attribute{
+			"cluster_id": schema.Int64Attribute{
+				MarkdownDescription: "集群 ID",
+				Required:            true,
+			},
+			"refresh": schema.BoolAttribute{
+				MarkdownDescription: "是否让平台重新采集节点网卡信息，默认使用平台缓存的结果",
+				Optional:            true,
+			},
+			"same_ifaces": schema.ListAttribute{
+				MarkdownDescription: "所有节点上都存在的网卡",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"master_same_ifaces": schema.ListAttribute{
+				MarkdownDescription: "所有 Master 节点上都存在的网卡",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"nodes": schema.ListNestedAttribute{
+				MarkdownDescription: "各节点的网卡信息",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"host": schema.StringAttribute{
+							MarkdownDescription: "节点 IP 地址",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "节点名称",
+							Computed:            true,
+						},
+						"is_master": schema.BoolAttribute{
+							MarkdownDescription: "是否为 Master 节点",
+							Computed:            true,
+						},
+						"ifaces": schema.ListAttribute{
+							MarkdownDescription: "节点上的网卡",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"iface_with_vlan": schema.ListAttribute{
+							MarkdownDescription: "节点上的网卡（包括 VLAN 子接口）",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"vlan_map": schema.MapAttribute{
+							MarkdownDescription: "VLAN 子接口与其父网卡的对应关系",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"bridge_map": schema.MapAttribute{
+							MarkdownDescription: "网桥与其绑定网卡的对应关系",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"routes": schema.MapNestedAttribute{
+							MarkdownDescription: "网卡的路由信息",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"interface": schema.StringAttribute{
+										MarkdownDescription: "出口网卡",
+										Computed:            true,
+									},
+									"ip_range": schema.StringAttribute{
+										MarkdownDescription: "网卡所在网段",
+										Computed:            true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ExternalNetworkInterfacesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.ZeClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.ZeClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ExternalNetworkInterfacesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ExternalNetworkInterfacesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterID := int(data.ClusterID.ValueInt64())
+
+	result, err := d.client.GetExternalNetworkCandidateInterface(clusterID, data.Refresh.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to query candidate interfaces",
+			fmt.Sprintf("Unable to query candidate interfaces of cluster %d, got error: %s", clusterID, err),
+		)
+		return
+	}
+
+	// 列表为 nil 时 Terraform 会得到 null，统一转换为空列表
+	data.SameIfaces = append([]string{}, result.SameIfaces...)
+	data.MasterSameIfaces = append([]string{}, result.MasterSameIfaces...)
+	data.Nodes = make([]ExternalNetworkNodeIfacesModel, 0, len(result.NodeIfaces))
+	for _, node := range result.NodeIfaces {
+		nodeModel := ExternalNetworkNodeIfacesModel{
+			Host:          types.StringValue(node.Host),
+			Name:          types.StringValue(node.Name),
+			IsMaster:      types.BoolValue(node.IsMaster),
+			Ifaces:        append([]string{}, node.Ifaces...),
+			IfaceWithVlan: append([]string{}, node.IfaceWithVlan...),
+			VlanMap:       maps.Clone(node.NodeIfaceMap.VlanMap),
+			BridgeMap:     maps.Clone(node.NodeIfaceMap.BrMap),
+			Routes:        make(map[string]ExternalNetworkIfaceRouteModel, len(node.NodeIfaceMap.RouteMap)),
+		}
+		for iface, route := range node.NodeIfaceMap.RouteMap {
+			nodeModel.Routes[iface] = ExternalNetworkIfaceRouteModel{
+				Interface: types.StringValue(route.Interface),
+				IpRange:   types.StringValue(route.IpRange),
+			}
+		}
+		data.Nodes = append(data.Nodes, nodeModel)
+	}
+
+	tflog.Debug(ctx, "Candidate interfaces retrieved", map[string]interface{}{
+		"cluster_id":  clusterID,
+		"same_ifaces": result.SameIfaces,
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// nodesMissingIface 返回没有指定网卡（包括 VLAN 子接口）的节点名称
+func nodesMissingIface(result *view.NodeIfaceAllResult, iface string) []string {
+	var missing []string
+	for _, node := range result.NodeIfaces {
+		if !slices.Contains(node.Ifaces, iface) && !slices.Contains(node.IfaceWithVlan, iface) {
+			missing = append(missing, node.Name)
+		}
+	}
+	return missing
+}
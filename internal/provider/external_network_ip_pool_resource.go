@@ -0,0 +1,599 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"zstack.io/edge-go-sdk/pkg/client"
+	"zstack.io/edge-go-sdk/pkg/param"
+)
+
+// ipPoolNamePattern 平台对 IP 池名称的要求
+var ipPoolNamePattern = regexp.MustCompile(`^[a-z][-a-z0-9]{0,48}[a-z0-9]$`)
+
+// ipPoolTypes IP 池类型：Svc（服务外部网络）、Pod（容器组附加网络）
+var ipPoolTypes = []string{
+	string(param.ExternalNetworkIpPoolTypeSvc),
+	string(param.ExternalNetworkIpPoolTypePod),
+}
+
+// ipPoolShareTypes IP 池共享方式：global（所有项目可用）、assign（仅分配的项目可用）
+var ipPoolShareTypes = []string{
+	string(param.ShareGlobal),
+	string(param.ShareAssign),
+}
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ExternalNetworkIpPoolResource{}
+var _ resource.ResourceWithImportState = &ExternalNetworkIpPoolResource{}
+var _ resource.ResourceWithValidateConfig = &ExternalNetworkIpPoolResource{}
+
+func NewExternalNetworkIpPoolResource() resource.Resource {
+	return &ExternalNetworkIpPoolResource{}
+}
+
+// ExternalNetworkIpPoolResource defines the resource implementation.
+type ExternalNetworkIpPoolResource struct {
+	client *client.ZeClient
+}
+
+// ExternalNetworkIpPoolResourceModel describes the resource data model.
+type ExternalNetworkIpPoolResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	ClusterID         types.Int64  `tfsdk:"cluster_id"`
+	ExternalNetworkID types.Int64  `tfsdk:"external_network_id"`
+	Name              types.String `tfsdk:"name"`
+	IpPoolType        types.String `tfsdk:"ip_pool_type"`
+	StartIP           types.String `tfsdk:"start_ip"`
+	EndIP             types.String `tfsdk:"end_ip"`
+	ShareType         types.String `tfsdk:"share_type"`
+	ProjectIDs        types.Set    `tfsdk:"project_ids"` // []int64
+
+	// Computed fields
+	PoolID     types.Int64  `tfsdk:"pool_id"`
+	IpTotalNum types.Int64  `tfsdk:"ip_total_num"`
+	IpUsedNum  types.Int64  `tfsdk:"ip_used_num"`
+	Disabled   types.Bool   `tfsdk:"disabled"`
+	CreateTime types.String `tfsdk:"create_time"`
+}
+
+func (r *ExternalNetworkIpPoolResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_external_network_ip_pool"
+}
+
+func (r *ExternalNetworkIpPoolResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "管理外部网络上的 IP 池。`Svc` 类型的 IP 池为服务（LoadBalancer）分配地址，`Pod` 类型的 IP 池为容器组附加网络分配地址。",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "IP 池资源 ID，格式为 `<cluster_id>/<external_network_id>/<name>`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cluster_id": schema.Int64Attribute{
+				MarkdownDescription: "集群 ID",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"external_network_id": schema.Int64Attribute{
+				MarkdownDescription: "外部网络 ID（`zstack_external_network` 的 `id`）",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "IP 池名称，2-50 个字符，以小写字母开头，只能包含小写字母、数字和 `-`",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringMatches(ipPoolNamePattern, "must be 2-50 characters of lowercase letters, digits and '-', starting with a letter and ending with a letter or digit"),
+				},
+			},
+			"ip_pool_type": schema.StringAttribute{
+				MarkdownDescription: "IP 池类型：`Svc`（服务外部网络）或 `Pod`（容器组附加网络）",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringOneOf(ipPoolTypes...),
+				},
+			},
+			"start_ip": schema.StringAttribute{
+				MarkdownDescription: "起始 IP 地址",
+				Required:            true,
+				Validators: []validator.String{
+					ipv4Address(),
+				},
+			},
+			"end_ip": schema.StringAttribute{
+				MarkdownDescription: "结束 IP 地址",
+				Required:            true,
+				Validators: []validator.String{
+					ipv4Address(),
+				},
+			},
+			"share_type": schema.StringAttribute{
+				MarkdownDescription: "共享方式：`global`（所有项目可用，默认）或 `assign`（仅 `project_ids` 中的项目可用）",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(string(param.ShareGlobal)),
+				Validators: []validator.String{
+					stringOneOf(ipPoolShareTypes...),
+				},
+			},
+			"project_ids": schema.SetAttribute{
+				MarkdownDescription: "可以使用该 IP 池的项目 ID，`share_type` 为 `assign` 时必须设置",
+				Optional:            true,
+				ElementType:         types.Int64Type,
+			},
+			"pool_id": schema.Int64Attribute{
+				MarkdownDescription: "平台中的 IP 池 ID",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"ip_total_num": schema.Int64Attribute{
+				MarkdownDescription: "IP 总数",
+				Computed:            true,
+			},
+			"ip_used_num": schema.Int64Attribute{
+				MarkdownDescription: "已使用的 IP 数量",
+				Computed:            true,
+			},
+			"disabled": schema.BoolAttribute{
+				MarkdownDescription: "IP 池是否被禁用",
+				Computed:            true,
+			},
+			"create_time": schema.StringAttribute{
+				MarkdownDescription: "创建时间",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ExternalNetworkIpPoolResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.ZeClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.ZeClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ExternalNetworkIpPoolResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ExternalNetworkIpPoolResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// 起始 IP 不能大于结束 IP
+	if !data.StartIP.IsUnknown() && !data.EndIP.IsUnknown() {
+		start, startErr := netip.ParseAddr(data.StartIP.ValueString())
+		end, endErr := netip.ParseAddr(data.EndIP.ValueString())
+		if startErr == nil && endErr == nil && start.Compare(end) > 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("end_ip"),
+				"Invalid IP range",
+				fmt.Sprintf("end_ip %s must not be lower than start_ip %s.", end, start),
+			)
+		}
+	}
+
+	if data.ShareType.IsUnknown() || data.ProjectIDs.IsUnknown() {
+		return
+	}
+
+	// assign 必须指定项目，global 不能指定项目
+	hasProjects := len(data.ProjectIDs.Elements()) > 0
+	switch data.ShareType.ValueString() {
+	case string(param.ShareAssign):
+		if !hasProjects {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("project_ids"),
+				"Missing project IDs",
+				"project_ids must contain at least one project when share_type is \"assign\".",
+			)
+		}
+	default:
+		if hasProjects {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("project_ids"),
+				"Unexpected project IDs",
+				"project_ids can only be set when share_type is \"assign\"; global IP pools are available to all projects.",
+			)
+		}
+	}
+}
+
+func (r *ExternalNetworkIpPoolResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ExternalNetworkIpPoolResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterID := int(data.ClusterID.ValueInt64())
+	networkID := data.ExternalNetworkID.ValueInt64()
+
+	projectIDs := r.projectIDs(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createParam := externalNetworkIpPoolCreateParam{
+		ExternalNetworkCreateIpPoolParam: param.ExternalNetworkCreateIpPoolParam{
+			ExternalNetworkIpPoolParam: param.ExternalNetworkIpPoolParam{
+				Name:       data.Name.ValueString(),
+				IpPoolType: param.ExternalNetworkIpPoolType(data.IpPoolType.ValueString()),
+			},
+			StartIp: data.StartIP.ValueString(),
+			EndIp:   data.EndIP.ValueString(),
+		},
+		ShareType:  param.ShareType(data.ShareType.ValueString()),
+		ProjectIDs: projectIDs,
+	}
+
+	tflog.Debug(ctx, "Creating external network IP pool", map[string]interface{}{
+		"cluster_id":          clusterID,
+		"external_network_id": networkID,
+		"name":                createParam.Name,
+		"start_ip":            createParam.StartIp,
+		"end_ip":              createParam.EndIp,
+	})
+
+	err := createExternalNetworkIpPool(r.client, clusterID, networkID, createParam)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to create IP pool",
+			fmt.Sprintf("Unable to create IP pool %q on external network %d, got error: %s", createParam.Name, networkID, err),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(externalNetworkIpPoolID(clusterID, networkID, data.Name.ValueString()))
+
+	found := r.readIpPool(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.Diagnostics.AddError(
+			"Failed to read IP pool after creation",
+			fmt.Sprintf("IP pool %q was created but is not listed on external network %d.", createParam.Name, networkID),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "Created external network IP pool", map[string]interface{}{"id": data.ID.ValueString()})
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ExternalNetworkIpPoolResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ExternalNetworkIpPoolResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found := r.readIpPool(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		tflog.Warn(ctx, "IP pool not found, removing from state", map[string]interface{}{
+			"id": data.ID.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ExternalNetworkIpPoolResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state ExternalNetworkIpPoolResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterID := int(data.ClusterID.ValueInt64())
+	networkID := data.ExternalNetworkID.ValueInt64()
+
+	if !requireIpPoolID(&state, &resp.Diagnostics) {
+		return
+	}
+
+	projectIDs := r.projectIDs(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateParam := externalNetworkIpPoolUpdateParam{
+		ExternalNetworkIpPoolUpdateParam: param.ExternalNetworkIpPoolUpdateParam{
+			ID:      int(state.PoolID.ValueInt64()),
+			Name:    data.Name.ValueString(),
+			StartIP: data.StartIP.ValueString(),
+			EndIP:   data.EndIP.ValueString(),
+		},
+		ShareType:  param.ShareType(data.ShareType.ValueString()),
+		ProjectIDs: projectIDs,
+	}
+
+	tflog.Debug(ctx, "Updating external network IP pool", map[string]interface{}{
+		"id":       data.ID.ValueString(),
+		"start_ip": updateParam.StartIP,
+		"end_ip":   updateParam.EndIP,
+	})
+
+	err := updateExternalNetworkIpPool(r.client, clusterID, networkID, updateParam)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to update IP pool",
+			fmt.Sprintf("Unable to update IP pool %q on external network %d, got error: %s", data.Name.ValueString(), networkID, err),
+		)
+		return
+	}
+
+	found := r.readIpPool(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.Diagnostics.AddError(
+			"Failed to read IP pool after update",
+			fmt.Sprintf("IP pool %q is no longer listed on external network %d.", data.Name.ValueString(), networkID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ExternalNetworkIpPoolResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ExternalNetworkIpPoolResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterID := int(data.ClusterID.ValueInt64())
+	networkID := data.ExternalNetworkID.ValueInt64()
+
+	// 重新读取，确认 IP 池中没有已分配的 IP
+	found := r.readIpPool(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() || !found {
+		return
+	}
+	if data.IpUsedNum.ValueInt64() > 0 {
+		resp.Diagnostics.AddError(
+			"IP pool is in use",
+			fmt.Sprintf("IP pool %q still has %d allocated IPs. Release them (delete the services or pods using them) and try again.",
+				data.Name.ValueString(), data.IpUsedNum.ValueInt64()),
+		)
+		return
+	}
+	if !requireIpPoolID(&data, &resp.Diagnostics) {
+		return
+	}
+
+	tflog.Debug(ctx, "Deleting external network IP pool", map[string]interface{}{
+		"id":      data.ID.ValueString(),
+		"pool_id": data.PoolID.ValueInt64(),
+	})
+
+	err := deleteExternalNetworkIpPool(r.client, clusterID, networkID, data.PoolID.ValueInt64())
+	if err != nil && !isNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Failed to delete IP pool",
+			fmt.Sprintf("Unable to delete IP pool %q from external network %d, got error: %s", data.Name.ValueString(), networkID, err),
+		)
+		return
+	}
+
+	// DELETE 返回不存在时也重新查询，确认 IP 池确实已被删除
+	if err := waitForExternalNetworkIpPoolDeleted(ctx, r.client, clusterID, networkID, data.Name.ValueString()); err != nil {
+		resp.Diagnostics.AddError("Failed to delete IP pool", err.Error())
+		return
+	}
+
+	tflog.Trace(ctx, "Deleted external network IP pool", map[string]interface{}{"id": data.ID.ValueString()})
+}
+
+func (r *ExternalNetworkIpPoolResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	clusterID, networkID, name, err := parseExternalNetworkIpPoolID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing IP pool ID",
+			fmt.Sprintf("Unable to parse IP pool ID '%s': %s. Expected format: <cluster_id>/<external_network_id>/<name>", req.ID, err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), externalNetworkIpPoolID(clusterID, networkID, name))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cluster_id"), int64(clusterID))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("external_network_id"), networkID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+}
+
+// requireIpPoolID 检查平台是否返回了 IP 池 ID。SDK 的 IP 池视图没有 id 字段，
+// 接口不返回时 pool_id 为 0，此时更新或删除会作用在错误的 IP 池上
+func requireIpPoolID(data *ExternalNetworkIpPoolResourceModel, diags *diag.Diagnostics) bool {
+	if data.PoolID.ValueInt64() > 0 {
+		return true
+	}
+	diags.AddError(
+		"IP pool ID unavailable",
+		fmt.Sprintf("The platform did not return an ID for IP pool %q on external network %d, so it cannot be updated or deleted safely. "+
+			"Manage this IP pool on the platform instead.", data.Name.ValueString(), data.ExternalNetworkID.ValueInt64()),
+	)
+	return false
+}
+
+// projectIDs 读取 project_ids 集合
+func (r *ExternalNetworkIpPoolResource) projectIDs(ctx context.Context, data *ExternalNetworkIpPoolResourceModel, diags *diag.Diagnostics) []int64 {
+	projectIDs := make([]int64, 0)
+	if !data.ProjectIDs.IsNull() {
+		diags.Append(data.ProjectIDs.ElementsAs(ctx, &projectIDs, false)...)
+	}
+	return projectIDs
+}
+
+// readIpPool 按名称查询 IP 池并更新属性，IP 池或外部网络不存在时返回 false
+func (r *ExternalNetworkIpPoolResource) readIpPool(ctx context.Context, data *ExternalNetworkIpPoolResourceModel, diags *diag.Diagnostics) bool {
+	clusterID := int(data.ClusterID.ValueInt64())
+	networkID := data.ExternalNetworkID.ValueInt64()
+	name := data.Name.ValueString()
+
+	pools, err := listExternalNetworkIpPools(r.client, clusterID, networkID)
+	if isNotFoundError(err) {
+		return false
+	}
+	if err != nil {
+		diags.AddError(
+			"Failed to read IP pool",
+			fmt.Sprintf("Unable to query IP pools of external network %d, got error: %s", networkID, err),
+		)
+		return false
+	}
+
+	var pool *externalNetworkIpPoolView
+	for i := range pools {
+		if pools[i].Name == name {
+			pool = &pools[i]
+			break
+		}
+	}
+	if pool == nil {
+		return false
+	}
+
+	data.PoolID = types.Int64Value(pool.ID)
+	data.IpPoolType = types.StringValue(string(pool.Type))
+	if pool.ShareType != "" {
+		data.ShareType = types.StringValue(string(pool.ShareType))
+	} else if data.ShareType.IsNull() {
+		data.ShareType = types.StringValue(string(param.ShareGlobal))
+	}
+	data.IpTotalNum = types.Int64Value(pool.IpTotalNum)
+	data.IpUsedNum = types.Int64Value(pool.IpUsedNum)
+	data.Disabled = types.BoolValue(pool.Disabled)
+	data.CreateTime = types.StringValue(pool.CreateTime.Format("2006-01-02 15:04:05"))
+
+	// 单个地址段时回写起止地址，以便发现平台上的修改
+	if len(pool.IpRanges) == 1 {
+		start, end, ok := parseIPRange(pool.IpRanges[0])
+		if ok {
+			data.StartIP = types.StringValue(start)
+			data.EndIP = types.StringValue(end)
+		}
+	}
+
+	if len(pool.ProjectIDs) > 0 {
+		projectIDs, d := types.SetValueFrom(ctx, types.Int64Type, pool.ProjectIDs)
+		diags.Append(d...)
+		data.ProjectIDs = projectIDs
+	} else {
+		data.ProjectIDs = types.SetNull(types.Int64Type)
+	}
+
+	tflog.Debug(ctx, "IP pool details retrieved", map[string]interface{}{
+		"id":           data.ID.ValueString(),
+		"ip_total_num": pool.IpTotalNum,
+		"ip_used_num":  pool.IpUsedNum,
+	})
+
+	return true
+}
+
+// parseIPRange 解析平台返回的地址段，格式为 <start>-<end> 或单个地址
+func parseIPRange(ipRange string) (string, string, bool) {
+	startStr, endStr, isRange := strings.Cut(strings.TrimSpace(ipRange), "-")
+	if !isRange {
+		endStr = startStr
+	}
+
+	start, err := netip.ParseAddr(strings.TrimSpace(startStr))
+	if err != nil {
+		return "", "", false
+	}
+	end, err := netip.ParseAddr(strings.TrimSpace(endStr))
+	if err != nil {
+		return "", "", false
+	}
+
+	return start.String(), end.String(), true
+}
+
+// externalNetworkIpPoolID 生成 IP 池资源 ID：<cluster_id>/<external_network_id>/<name>
+func externalNetworkIpPoolID(clusterID int, networkID int64, name string) string {
+	return fmt.Sprintf("%d/%d/%s", clusterID, networkID, name)
+}
+
+// parseExternalNetworkIpPoolID 解析 <cluster_id>/<external_network_id>/<name> 格式的 IP 池资源 ID
+func parseExternalNetworkIpPoolID(id string) (int, int64, string, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != 3 || parts[2] == "" {
+		return 0, 0, "", fmt.Errorf("expected 3 parts separated by '/'")
+	}
+
+	clusterID, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, "", fmt.Errorf("invalid cluster ID %q", parts[0])
+	}
+
+	networkID, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, 0, "", fmt.Errorf("invalid external network ID %q", parts[1])
+	}
+
+	return clusterID, networkID, parts[2], nil
+}
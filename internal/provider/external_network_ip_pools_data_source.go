@@ -0,0 +1,306 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"zstack.io/edge-go-sdk/pkg/client"
+	"zstack.io/edge-go-sdk/pkg/param"
+)
+
+// ipPoolFilterFields filter 块可以使用的字段。IP 池接口不支持查询条件，字段都不设置 query，全部在本地过滤
+var ipPoolFilterFields = map[string]queryFilterField[externalNetworkIpPoolView]{
+	"id":            {value: func(p externalNetworkIpPoolView) string { return strconv.FormatInt(p.ID, 10) }},
+	"name":          {value: func(p externalNetworkIpPoolView) string { return p.Name }},
+	"l2_name":       {value: func(p externalNetworkIpPoolView) string { return p.L2Name }},
+	"type":          {value: func(p externalNetworkIpPoolView) string { return string(p.Type) }},
+	"share_type":    {value: func(p externalNetworkIpPoolView) string { return string(p.ShareType) }},
+	"ip_total_num":  {value: func(p externalNetworkIpPoolView) string { return strconv.FormatInt(p.IpTotalNum, 10) }},
+	"ip_used_num":   {value: func(p externalNetworkIpPoolView) string { return strconv.FormatInt(p.IpUsedNum, 10) }},
+	"exist_ip_used": {value: func(p externalNetworkIpPoolView) string { return strconv.FormatBool(p.ExistIpUsed) }},
+	"disabled":      {value: func(p externalNetworkIpPoolView) string { return strconv.FormatBool(p.Disabled) }},
+}
+
+var _ datasource.DataSource = &ExternalNetworkIpPoolsDataSource{}
+
+func NewExternalNetworkIpPoolsDataSource() datasource.DataSource {
+	return &ExternalNetworkIpPoolsDataSource{}
+}
+
+type ExternalNetworkIpPoolsDataSource struct {
+	client *client.ZeClient
+}
+
+type ExternalNetworkIpPoolsDataSourceModel struct {
+	ClusterID          types.Int64                            `tfsdk:"cluster_id"`
+	ExternalNetworkID  types.Int64                            `tfsdk:"external_network_id"`
+	Name               types.String                           `tfsdk:"name"`
+	IpPoolType         types.String                           `tfsdk:"ip_pool_type"`
+	Pools              []ExternalNetworkIpPoolDataSourceModel `tfsdk:"pools"`
+	IpTotalNum         types.Int64                            `tfsdk:"ip_total_num"`
+	IpUsedNum          types.Int64                            `tfsdk:"ip_used_num"`
+	UtilizationPercent types.Float64                          `tfsdk:"utilization_percent"`
+	Filter             []QueryFilterModel                     `tfsdk:"filter"`
+}
+
+type ExternalNetworkIpPoolDataSourceModel struct {
+	ID                 types.Int64    `tfsdk:"id"`
+	Name               types.String   `tfsdk:"name"`
+	L2Name             types.String   `tfsdk:"l2_name"`
+	Type               types.String   `tfsdk:"type"`
+	ShareType          types.String   `tfsdk:"share_type"`
+	ProjectIDs         []types.Int64  `tfsdk:"project_ids"`
+	IpRanges           []types.String `tfsdk:"ip_ranges"`
+	Ip6Ranges          []types.String `tfsdk:"ip6_ranges"`
+	IpTotalNum         types.Int64    `tfsdk:"ip_total_num"`
+	IpUsedNum          types.Int64    `tfsdk:"ip_used_num"`
+	IpFreeNum          types.Int64    `tfsdk:"ip_free_num"`
+	UtilizationPercent types.Float64  `tfsdk:"utilization_percent"`
+	ExistIpUsed        types.Bool     `tfsdk:"exist_ip_used"`
+	Disabled           types.Bool     `tfsdk:"disabled"`
+	CreateTime         types.String   `tfsdk:"create_time"`
+}
+
+func (d *ExternalNetworkIpPoolsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_external_network_ip_pools"
+}
+
+func (d *ExternalNetworkIpPoolsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "查询外部网络上的 IP 池及其使用率。`utilization_percent` 可在 `check` 块中用于 IP 池容量告警。",
+
+		Attributes: map[string]schema.Attribute{
+			"cluster_id": schema.Int64Attribute{
+				MarkdownDescription: "集群 ID",
+				Required:            true,
+			},
+			"external_network_id": schema.Int64Attribute{
+				MarkdownDescription: "外部网络 ID",
+				Required:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "IP 池名称（可选，用于过滤）",
+				Optional:            true,
+			},
+			"ip_pool_type": schema.StringAttribute{
+				MarkdownDescription: "IP 池类型（可选，用于过滤）：`Svc` 或 `Pod`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringOneOf(ipPoolTypes...),
+				},
+			},
+			"pools": schema.ListNestedAttribute{
+				MarkdownDescription: "IP 池列表",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							MarkdownDescription: "IP 池 ID",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "IP 池名称",
+							Computed:            true,
+						},
+						"l2_name": schema.StringAttribute{
+							MarkdownDescription: "二层网络名称",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "IP 池类型：`Svc` 或 `Pod`",
+							Computed:            true,
+						},
+						"share_type": schema.StringAttribute{
+							MarkdownDescription: "共享方式：`global` 或 `assign`",
+							Computed:            true,
+						},
+						"project_ids": schema.ListAttribute{
+							MarkdownDescription: "分配的项目 ID",
+							Computed:            true,
+							ElementType:         types.Int64Type,
+						},
+						"ip_ranges": schema.ListAttribute{
+							MarkdownDescription: "IPv4 地址段",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"ip6_ranges": schema.ListAttribute{
+							MarkdownDescription: "IPv6 地址段",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"ip_total_num": schema.Int64Attribute{
+							MarkdownDescription: "IP 总数",
+							Computed:            true,
+						},
+						"ip_used_num": schema.Int64Attribute{
+							MarkdownDescription: "已使用的 IP 数量",
+							Computed:            true,
+						},
+						"ip_free_num": schema.Int64Attribute{
+							MarkdownDescription: "剩余可用的 IP 数量",
+							Computed:            true,
+						},
+						"utilization_percent": schema.Float64Attribute{
+							MarkdownDescription: "IP 使用率（百分比，保留两位小数）",
+							Computed:            true,
+						},
+						"exist_ip_used": schema.BoolAttribute{
+							MarkdownDescription: "是否存在已分配的 IP",
+							Computed:            true,
+						},
+						"disabled": schema.BoolAttribute{
+							MarkdownDescription: "IP 池是否被禁用",
+							Computed:            true,
+						},
+						"create_time": schema.StringAttribute{
+							MarkdownDescription: "创建时间",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"ip_total_num": schema.Int64Attribute{
+				MarkdownDescription: "所有匹配 IP 池的 IP 总数",
+				Computed:            true,
+			},
+			"ip_used_num": schema.Int64Attribute{
+				MarkdownDescription: "所有匹配 IP 池已使用的 IP 数量",
+				Computed:            true,
+			},
+			"utilization_percent": schema.Float64Attribute{
+				MarkdownDescription: "所有匹配 IP 池的整体使用率（百分比，保留两位小数）",
+				Computed:            true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"filter": queryFilterBlock(ipPoolFilterFields),
+		},
+	}
+}
+
+func (d *ExternalNetworkIpPoolsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.ZeClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.ZeClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ExternalNetworkIpPoolsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ExternalNetworkIpPoolsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterID := int(data.ClusterID.ValueInt64())
+	networkID := data.ExternalNetworkID.ValueInt64()
+
+	// 所有字段都在本地过滤，不会向 queryParam 添加查询条件
+	queryParam := param.NewQueryParam()
+	match, err := applyQueryFilters(data.Filter, ipPoolFilterFields, &queryParam)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("filter"), "Invalid filter", err.Error())
+		return
+	}
+
+	pools, err := listExternalNetworkIpPools(d.client, clusterID, networkID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to query IP pools",
+			fmt.Sprintf("Unable to query IP pools of external network %d in cluster %d, got error: %s", networkID, clusterID, err),
+		)
+		return
+	}
+
+	var totalNum, usedNum int64
+	data.Pools = make([]ExternalNetworkIpPoolDataSourceModel, 0, len(pools))
+	for _, pool := range pools {
+		// 平台接口不支持按名称和类型过滤，在本地过滤
+		if !data.Name.IsNull() && pool.Name != data.Name.ValueString() {
+			continue
+		}
+		if !data.IpPoolType.IsNull() && string(pool.Type) != data.IpPoolType.ValueString() {
+			continue
+		}
+		if !match(pool) {
+			continue
+		}
+
+		poolModel := ExternalNetworkIpPoolDataSourceModel{
+			ID:                 types.Int64Value(pool.ID),
+			Name:               types.StringValue(pool.Name),
+			L2Name:             types.StringValue(pool.L2Name),
+			Type:               types.StringValue(string(pool.Type)),
+			ShareType:          types.StringValue(string(pool.ShareType)),
+			ProjectIDs:         make([]types.Int64, 0, len(pool.ProjectIDs)),
+			IpRanges:           make([]types.String, 0, len(pool.IpRanges)),
+			Ip6Ranges:          make([]types.String, 0, len(pool.Ip6Ranges)),
+			IpTotalNum:         types.Int64Value(pool.IpTotalNum),
+			IpUsedNum:          types.Int64Value(pool.IpUsedNum),
+			IpFreeNum:          types.Int64Value(max(pool.IpTotalNum-pool.IpUsedNum, 0)),
+			UtilizationPercent: types.Float64Value(utilizationPercent(pool.IpUsedNum, pool.IpTotalNum)),
+			ExistIpUsed:        types.BoolValue(pool.ExistIpUsed),
+			Disabled:           types.BoolValue(pool.Disabled),
+			CreateTime:         types.StringValue(pool.CreateTime.Format("2006-01-02 15:04:05")),
+		}
+		for _, projectID := range pool.ProjectIDs {
+			poolModel.ProjectIDs = append(poolModel.ProjectIDs, types.Int64Value(projectID))
+		}
+		for _, ipRange := range pool.IpRanges {
+			poolModel.IpRanges = append(poolModel.IpRanges, types.StringValue(ipRange))
+		}
+		for _, ipRange := range pool.Ip6Ranges {
+			poolModel.Ip6Ranges = append(poolModel.Ip6Ranges, types.StringValue(ipRange))
+		}
+		data.Pools = append(data.Pools, poolModel)
+
+		totalNum += pool.IpTotalNum
+		usedNum += pool.IpUsedNum
+	}
+
+	data.IpTotalNum = types.Int64Value(totalNum)
+	data.IpUsedNum = types.Int64Value(usedNum)
+	data.UtilizationPercent = types.Float64Value(utilizationPercent(usedNum, totalNum))
+
+	tflog.Debug(ctx, "IP pools retrieved", map[string]interface{}{
+		"external_network_id": networkID,
+		"count":               len(data.Pools),
+		"ip_total_num":        totalNum,
+		"ip_used_num":         usedNum,
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// utilizationPercent 计算使用率百分比，保留两位小数；总数为 0 时返回 0
+func utilizationPercent(used, total int64) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return math.Round(float64(used)*10000/float64(total)) / 100
+}
@@ -0,0 +1,306 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"zstack.io/edge-go-sdk/pkg/client"
+)
+
+// ipReservations 记录本进程中已分配给 zstack_external_network_ip 资源的地址，
+// 只能避免同一个 provider 进程中并行创建的资源拿到同一个地址。
+// 平台没有预留接口，地址在被服务使用前仍会报告为可用；apply 使用新的 provider 进程，
+// 也不会读取 state 中已有的资源，因此无法避免与之前 apply 中尚未使用的地址重复。
+var ipReservations = struct {
+	sync.Mutex
+	reserved map[string]bool
+}{reserved: make(map[string]bool)}
+
+func ipReservationKey(clusterID int, network, address string) string {
+	return fmt.Sprintf("%d/%s/%s", clusterID, network, address)
+}
+
+// reserveExternalNetworkIP 从可用地址中选出一个未被本进程占用的地址并登记。
+// requested 不为空时只尝试该地址。
+func reserveExternalNetworkIP(clusterID int, network string, available []string, requested string) (string, error) {
+	ipReservations.Lock()
+	defer ipReservations.Unlock()
+
+	if requested != "" {
+		if !slices.Contains(available, requested) {
+			return "", fmt.Errorf("address %s is not available on external network %q: it is already in use or outside the network's IP pools", requested, network)
+		}
+		key := ipReservationKey(clusterID, network, requested)
+		if ipReservations.reserved[key] {
+			return "", fmt.Errorf("address %s is already reserved by another zstack_external_network_ip resource", requested)
+		}
+		ipReservations.reserved[key] = true
+		return requested, nil
+	}
+
+	// 按地址顺序选择，结果可预期
+	addrs := make([]netip.Addr, 0, len(available))
+	for _, a := range available {
+		if addr, err := netip.ParseAddr(a); err == nil {
+			addrs = append(addrs, addr)
+		}
+	}
+	slices.SortFunc(addrs, netip.Addr.Compare)
+
+	for _, addr := range addrs {
+		key := ipReservationKey(clusterID, network, addr.String())
+		if !ipReservations.reserved[key] {
+			ipReservations.reserved[key] = true
+			return addr.String(), nil
+		}
+	}
+
+	return "", fmt.Errorf("no free address left on external network %q (%d available, all reserved in this run)", network, len(available))
+}
+
+// markExternalNetworkIPReserved 登记已有资源持有的地址
+func markExternalNetworkIPReserved(clusterID int, network, address string) {
+	ipReservations.Lock()
+	defer ipReservations.Unlock()
+	ipReservations.reserved[ipReservationKey(clusterID, network, address)] = true
+}
+
+// releaseExternalNetworkIP 释放本进程中登记的地址
+func releaseExternalNetworkIP(clusterID int, network, address string) {
+	ipReservations.Lock()
+	defer ipReservations.Unlock()
+	delete(ipReservations.reserved, ipReservationKey(clusterID, network, address))
+}
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ExternalNetworkIPResource{}
+var _ resource.ResourceWithImportState = &ExternalNetworkIPResource{}
+
+func NewExternalNetworkIPResource() resource.Resource {
+	return &ExternalNetworkIPResource{}
+}
+
+// ExternalNetworkIPResource defines the resource implementation.
+type ExternalNetworkIPResource struct {
+	client *client.ZeClient
+}
+
+// ExternalNetworkIPResourceModel describes the resource data model.
+type ExternalNetworkIPResourceModel struct {
+	ID                  types.String `tfsdk:"id"`
+	ClusterID           types.Int64  `tfsdk:"cluster_id"`
+	ExternalNetworkName types.String `tfsdk:"external_network_name"`
+	Address             types.String `tfsdk:"address"`
+}
+
+func (r *ExternalNetworkIPResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_external_network_ip"
+}
+
+func (r *ExternalNetworkIPResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "从外部网络的可用地址中选取一个 IP，并在 state 中保持不变，用作服务（LoadBalancer）的固定 IP。\n\n" +
+			"地址在创建时通过 `GetExternalNetworkAvailableIps` 选取平台报告为可用的最小地址。" +
+			"平台没有预留接口，地址在被服务使用之前仍会显示为可用，因此**不保证**与其他资源的地址不同：" +
+			"之前创建但尚未被服务使用的地址会被再次选中，自动选取的资源也可能先拿走其他资源显式指定的地址。" +
+			"Provider 只能避免同一次创建过程中并行创建的资源重复。需要多个地址时建议显式设置 `address`，" +
+			"或在地址被服务使用后再创建下一个资源。",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "资源 ID，格式为 `<cluster_id>/<external_network_name>/<address>`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cluster_id": schema.Int64Attribute{
+				MarkdownDescription: "集群 ID",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"external_network_name": schema.StringAttribute{
+				MarkdownDescription: "外部网络名称",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"address": schema.StringAttribute{
+				MarkdownDescription: "IP 地址。指定时使用该地址（地址已被占用时创建失败），未指定时自动选取一个可用地址",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					ipv4Address(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ExternalNetworkIPResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.ZeClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.ZeClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ExternalNetworkIPResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ExternalNetworkIPResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterID := int(data.ClusterID.ValueInt64())
+	network := data.ExternalNetworkName.ValueString()
+
+	available, err := r.client.GetExternalNetworkAvailableIps(clusterID, network)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to query available IPs",
+			fmt.Sprintf("Unable to query available IPs of external network %q in cluster %d, got error: %s", network, clusterID, err),
+		)
+		return
+	}
+
+	requested := ""
+	if !data.Address.IsUnknown() && !data.Address.IsNull() {
+		requested = data.Address.ValueString()
+	}
+
+	address, err := reserveExternalNetworkIP(clusterID, network, available, requested)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("address"), "Failed to reserve IP", err.Error())
+		return
+	}
+
+	data.Address = types.StringValue(address)
+	data.ID = types.StringValue(externalNetworkIPID(clusterID, network, address))
+
+	tflog.Info(ctx, "Reserved external network IP", map[string]interface{}{
+		"cluster_id": clusterID,
+		"network":    network,
+		"address":    address,
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ExternalNetworkIPResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ExternalNetworkIPResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// 地址被服务使用后不再出现在可用列表中，这是预期行为，因此不从平台刷新。
+	// 登记地址只对 refresh 与创建在同一进程中的情况有效，见 ipReservations 的说明。
+	markExternalNetworkIPReserved(int(data.ClusterID.ValueInt64()), data.ExternalNetworkName.ValueString(), data.Address.ValueString())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ExternalNetworkIPResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ExternalNetworkIPResourceModel
+
+	// 所有属性变化都会替换资源，这里只保存计划值
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ExternalNetworkIPResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ExternalNetworkIPResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	releaseExternalNetworkIP(int(data.ClusterID.ValueInt64()), data.ExternalNetworkName.ValueString(), data.Address.ValueString())
+
+	tflog.Info(ctx, "Released external network IP", map[string]interface{}{
+		"id": data.ID.ValueString(),
+	})
+}
+
+func (r *ExternalNetworkIPResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	clusterID, network, address, err := parseExternalNetworkIPID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing external network IP ID",
+			fmt.Sprintf("Unable to parse ID '%s': %s. Expected format: <cluster_id>/<external_network_name>/<address>", req.ID, err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), externalNetworkIPID(clusterID, network, address))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cluster_id"), int64(clusterID))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("external_network_name"), network)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("address"), address)...)
+}
+
+// externalNetworkIPID 生成资源 ID：<cluster_id>/<external_network_name>/<address>
+func externalNetworkIPID(clusterID int, network, address string) string {
+	return fmt.Sprintf("%d/%s/%s", clusterID, network, address)
+}
+
+// parseExternalNetworkIPID 解析 <cluster_id>/<external_network_name>/<address> 格式的资源 ID
+func parseExternalNetworkIPID(id string) (int, string, string, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != 3 || parts[1] == "" || parts[2] == "" {
+		return 0, "", "", fmt.Errorf("expected 3 parts separated by '/'")
+	}
+
+	clusterID, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, "", "", fmt.Errorf("invalid cluster ID %q", parts[0])
+	}
+
+	addr, err := netip.ParseAddr(parts[2])
+	if err != nil || !addr.Is4() {
+		return 0, "", "", fmt.Errorf("invalid IPv4 address %q", parts[2])
+	}
+
+	return clusterID, parts[1], addr.String(), nil
+}
@@ -5,25 +5,37 @@ package provider
 
 import (
 	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
+	"maps"
+	"net"
+	"net/netip"
+	"slices"
 	"strconv"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"zstack.io/edge-go-sdk/pkg/client"
 	"zstack.io/edge-go-sdk/pkg/param"
+	"zstack.io/edge-go-sdk/pkg/view"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &ExternalNetworkResource{}
 var _ resource.ResourceWithImportState = &ExternalNetworkResource{}
+var _ resource.ResourceWithModifyPlan = &ExternalNetworkResource{}
+var _ resource.ResourceWithValidateConfig = &ExternalNetworkResource{}
 
 func NewExternalNetworkResource() resource.Resource {
 	return &ExternalNetworkResource{}
@@ -45,9 +57,15 @@ type ExternalNetworkResourceModel struct {
 	Interface   types.String `tfsdk:"interface"`
 
 	// Computed fields
-	Status     types.String `tfsdk:"status"`
-	CreateTime types.String `tfsdk:"create_time"`
-	UpdateTime types.String `tfsdk:"update_time"`
+	Status          types.String `tfsdk:"status"`
+	Type            types.String `tfsdk:"type"`
+	Cidr            types.String `tfsdk:"cidr"`
+	IpTotalNum      types.Int64  `tfsdk:"ip_total_num"`
+	IpUsedNum       types.Int64  `tfsdk:"ip_used_num"`
+	SpiderPoolReady types.Bool   `tfsdk:"spider_pool_ready"`
+	MetallbReady    types.Bool   `tfsdk:"metallb_ready"`
+	CreateTime      types.String `tfsdk:"create_time"`
+	UpdateTime      types.String `tfsdk:"update_time"`
 }
 
 func (r *ExternalNetworkResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -56,7 +74,8 @@ func (r *ExternalNetworkResource) Metadata(ctx context.Context, req resource.Met
 
 func (r *ExternalNetworkResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "管理 ZStack Edge 外部网络资源。提供外部网络的创建、读取和删除功能。",
+		MarkdownDescription: "管理 ZStack Edge 外部网络资源。提供外部网络的创建、读取和删除功能，" +
+			"修改任何可配置属性都会替换外部网络。删除前会检查 IP 池中是否仍有已分配的 IP。",
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -69,38 +88,107 @@ func (r *ExternalNetworkResource) Schema(ctx context.Context, req resource.Schem
 			"cluster_id": schema.Int64Attribute{
 				MarkdownDescription: "集群 ID",
 				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
 			},
 			"name": schema.StringAttribute{
 				MarkdownDescription: "外部网络名称",
 				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"description": schema.StringAttribute{
 				MarkdownDescription: "外部网络描述",
 				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"gateway": schema.StringAttribute{
-				MarkdownDescription: "网关地址",
-				Required:            true,
+				MarkdownDescription: "网关地址，必须位于子网内。设置 `cidr` 时可省略，默认为网段的第一个地址",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					ipv4Address(),
+				},
 			},
 			"netmask": schema.StringAttribute{
-				MarkdownDescription: "子网掩码",
-				Required:            true,
+				MarkdownDescription: "子网掩码（点分十进制，如 `255.255.255.0`）。与 `cidr` 二选一",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					ipv4Netmask(),
+				},
 			},
 			"interface": schema.StringAttribute{
 				MarkdownDescription: "网卡接口名称",
 				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"status": schema.StringAttribute{
 				MarkdownDescription: "外部网络状态",
 				Computed:            true,
 			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "网络类型：`manager`（管理网络）或 `business`（业务网络）",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cidr": schema.StringAttribute{
+				MarkdownDescription: "外部网络的 IPv4 网段（如 `192.168.10.0/24`）。可代替 `netmask` 设置，" +
+					"未设置 `gateway` 时使用网段的第一个地址作为网关；未设置时由 `gateway` 和 `netmask` 计算得到。" +
+					"网段不能与集群的 Pod/Service CIDR 或集群中其他外部网络重叠",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					ipv4CIDR(),
+				},
+			},
+			"ip_total_num": schema.Int64Attribute{
+				MarkdownDescription: "IP 池中的 IP 总数",
+				Computed:            true,
+			},
+			"ip_used_num": schema.Int64Attribute{
+				MarkdownDescription: "已使用的 IP 数量",
+				Computed:            true,
+			},
+			"spider_pool_ready": schema.BoolAttribute{
+				MarkdownDescription: "SpiderPool（容器组附加网络）是否就绪",
+				Computed:            true,
+			},
+			"metallb_ready": schema.BoolAttribute{
+				MarkdownDescription: "MetalLB（服务负载均衡）是否就绪",
+				Computed:            true,
+			},
 			"create_time": schema.StringAttribute{
 				MarkdownDescription: "创建时间",
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"update_time": schema.StringAttribute{
-				MarkdownDescription: "更新时间",
+				MarkdownDescription: "更新时间。平台不返回更新时间，该值始终与 `create_time` 相同",
 				Computed:            true,
+				DeprecationMessage:  "The platform does not report an update time; this attribute always equals create_time and will be removed in a future version.",
 			},
 		},
 	}
@@ -123,6 +211,233 @@ func (r *ExternalNetworkResource) Configure(ctx context.Context, req resource.Co
 	r.client = client
 }
 
+func (r *ExternalNetworkResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config ExternalNetworkResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// cidr 与 netmask 二选一；未设置 cidr 时必须同时设置 gateway 和 netmask
+	if config.Cidr.IsNull() {
+		for name, value := range map[string]types.String{"gateway": config.Gateway, "netmask": config.Netmask} {
+			if value.IsNull() {
+				resp.Diagnostics.AddAttributeError(
+					path.Root(name),
+					"Missing required argument",
+					fmt.Sprintf("%s is required when cidr is not set.", name),
+				)
+			}
+		}
+	} else if !config.Netmask.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("netmask"),
+			"Conflicting arguments",
+			"netmask is derived from cidr; set either cidr or netmask, not both.",
+		)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Gateway.IsNull() || config.Gateway.IsUnknown() {
+		return
+	}
+	prefix, ok := configuredPrefix(&config)
+	if !ok {
+		return
+	}
+	gateway, err := netip.ParseAddr(config.Gateway.ValueString())
+	if err != nil {
+		return
+	}
+
+	// 网关必须是子网内可用的主机地址
+	switch {
+	case !prefix.Contains(gateway):
+		resp.Diagnostics.AddAttributeError(
+			path.Root("gateway"),
+			"Gateway outside subnet",
+			fmt.Sprintf("Gateway %s is not inside subnet %s.", gateway, prefix),
+		)
+	case prefix.Bits() < 31 && (gateway == prefix.Addr() || gateway == prefixBroadcast(prefix)):
+		resp.Diagnostics.AddAttributeError(
+			path.Root("gateway"),
+			"Invalid gateway",
+			fmt.Sprintf("Gateway %s is the network or broadcast address of subnet %s.", gateway, prefix),
+		)
+	}
+}
+
+func (r *ExternalNetworkResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// 销毁资源时无需检查
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var data, config, state ExternalNetworkResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	creating := req.State.Raw.IsNull()
+	if !creating {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	planAddressing(&data, &config)
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// 推导出的地址（如去掉 gateway 后使用网段的第一个地址）不经过属性的 plan modifier，在这里判断是否需要替换
+	if !creating {
+		for name, values := range map[string][2]types.String{
+			"gateway": {data.Gateway, state.Gateway},
+			"netmask": {data.Netmask, state.Netmask},
+			"cidr":    {data.Cidr, state.Cidr},
+		} {
+			if !values[0].Equal(values[1]) {
+				resp.RequiresReplace.Append(path.Root(name))
+			}
+		}
+	}
+
+	if data.ClusterID.IsUnknown() || r.client == nil {
+		return
+	}
+	clusterID := int(data.ClusterID.ValueInt64())
+	clusterChanged := creating || !data.ClusterID.Equal(state.ClusterID)
+
+	if !data.Interface.IsUnknown() && (clusterChanged || !data.Interface.Equal(state.Interface)) {
+		r.checkInterface(ctx, clusterID, data.Interface.ValueString(), &resp.Diagnostics)
+	}
+
+	if !data.Cidr.IsUnknown() && (clusterChanged || !data.Cidr.Equal(state.Cidr)) {
+		prefix, err := netip.ParsePrefix(data.Cidr.ValueString())
+		if err != nil {
+			return
+		}
+		attrPath := path.Root("netmask")
+		if !config.Cidr.IsNull() {
+			attrPath = path.Root("cidr")
+		}
+		r.checkOverlaps(ctx, clusterID, prefix, state.ID.ValueString(), attrPath, &resp.Diagnostics)
+	}
+}
+
+// planAddressing 由 cidr 推导 netmask 和 gateway，或由 gateway 和 netmask 计算 cidr
+func planAddressing(data, config *ExternalNetworkResourceModel) {
+	if !config.Cidr.IsNull() {
+		if config.Cidr.IsUnknown() {
+			data.Netmask = types.StringUnknown()
+			if config.Gateway.IsNull() {
+				data.Gateway = types.StringUnknown()
+			}
+			return
+		}
+
+		prefix, err := netip.ParsePrefix(config.Cidr.ValueString())
+		if err != nil {
+			return
+		}
+		data.Netmask = types.StringValue(prefixNetmask(prefix.Bits()))
+		if config.Gateway.IsNull() {
+			data.Gateway = types.StringValue(prefix.Addr().Next().String())
+		}
+		return
+	}
+
+	if data.Gateway.IsUnknown() || data.Netmask.IsUnknown() {
+		data.Cidr = types.StringUnknown()
+		return
+	}
+	if prefix, ok := externalNetworkPrefix(data.Gateway.ValueString(), data.Netmask.ValueString()); ok {
+		data.Cidr = types.StringValue(prefix.String())
+	}
+}
+
+// checkOverlaps 确认网段不与集群的 Pod/Service CIDR 及集群中其他外部网络重叠
+func (r *ExternalNetworkResource) checkOverlaps(ctx context.Context, clusterID int, prefix netip.Prefix, ownID string, attrPath path.Path, diags *diag.Diagnostics) {
+	var conflicts []string
+
+	// 查询失败时跳过对应的检查，交给创建接口报告错误
+	clusterDetails, err := r.client.GetClusterDetails(clusterID)
+	if err != nil {
+		tflog.Warn(ctx, "Unable to read cluster, skipping Pod/Service CIDR overlap check", map[string]interface{}{
+			"cluster_id": clusterID,
+			"error":      err.Error(),
+		})
+	} else {
+		for _, cidr := range clusterCIDRs(clusterDetails.Config) {
+			if other, err := netip.ParsePrefix(cidr.value); err == nil && other.Overlaps(prefix) {
+				conflicts = append(conflicts, fmt.Sprintf("the cluster %s CIDR %s", cidr.name, other))
+			}
+		}
+	}
+
+	networks, err := listExternalNetworks(r.client, clusterID)
+	if err != nil {
+		tflog.Warn(ctx, "Unable to list external networks, skipping overlap check", map[string]interface{}{
+			"cluster_id": clusterID,
+			"error":      err.Error(),
+		})
+	} else {
+		for _, network := range networks {
+			if strconv.FormatInt(network.ID, 10) == ownID {
+				continue
+			}
+			if other, ok := networkPrefix(&network); ok && other.Overlaps(prefix) {
+				conflicts = append(conflicts, fmt.Sprintf("external network %q (%s)", network.Name, other))
+			}
+		}
+	}
+
+	if len(conflicts) > 0 {
+		diags.AddAttributeError(
+			attrPath,
+			"Overlapping network",
+			fmt.Sprintf("Network %s of cluster %d overlaps %s.", prefix, clusterID, strings.Join(conflicts, ", ")),
+		)
+	}
+}
+
+// checkInterface 确认网卡在集群的每个节点上都存在
+func (r *ExternalNetworkResource) checkInterface(ctx context.Context, clusterID int, iface string, diags *diag.Diagnostics) {
+	result, err := r.client.GetExternalNetworkCandidateInterface(clusterID, false)
+	if err != nil {
+		// 查询失败时交给创建接口报告错误
+		tflog.Warn(ctx, "Unable to query candidate interfaces, skipping interface validation", map[string]interface{}{
+			"cluster_id": clusterID,
+			"error":      err.Error(),
+		})
+		return
+	}
+
+	if slices.Contains(result.SameIfaces, iface) {
+		return
+	}
+
+	detail := fmt.Sprintf("Interface %q is not available on every node of cluster %d.", iface, clusterID)
+	if missing := nodesMissingIface(result, iface); len(missing) > 0 {
+		detail += fmt.Sprintf(" It is missing on nodes %v.", missing)
+	}
+	if len(result.SameIfaces) > 0 {
+		detail += fmt.Sprintf(" Interfaces present on all nodes: %v.", result.SameIfaces)
+	} else {
+		detail += " No interface is present on all nodes."
+	}
+	detail += " Use the zstack_external_network_interfaces data source to list candidate interfaces."
+
+	diags.AddAttributeError(path.Root("interface"), "Invalid network interface", detail)
+}
+
 func (r *ExternalNetworkResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data ExternalNetworkResourceModel
 
@@ -181,6 +496,19 @@ func (r *ExternalNetworkResource) Create(ctx context.Context, req resource.Creat
 		return
 	}
 
+	// 等待 SpiderPool 和 MetalLB 就绪，此前创建 IP 池或服务会失败
+	networkID, _ := strconv.ParseInt(data.ID.ValueString(), 10, 64)
+	network, err := waitForExternalNetworkReady(ctx, r.client, createParam.ClusterID, networkID)
+	if network != nil {
+		setExternalNetworkModel(&data, network)
+	}
+	if err != nil {
+		// 网络已经创建，保存状态避免遗留；资源会被标记为 taint
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		resp.Diagnostics.AddError("External network did not become ready", err.Error())
+		return
+	}
+
 	tflog.Trace(ctx, "Created external network resource", map[string]interface{}{
 		"id":   data.ID.ValueString(),
 		"name": data.Name.ValueString(),
@@ -210,7 +538,7 @@ func (r *ExternalNetworkResource) Read(ctx context.Context, req resource.ReadReq
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		
+
 		resp.Diagnostics.AddError("Failed to read external network", err.Error())
 		return
 	}
@@ -226,7 +554,7 @@ func (r *ExternalNetworkResource) Update(ctx context.Context, req resource.Updat
 		return
 	}
 
-	// 外部网络目前不支持更新操作
+	// 外部网络目前不支持更新操作，所有可配置属性的变化都会替换资源
 	resp.Diagnostics.AddError(
 		"Update not supported",
 		"External network does not support update operations. Please destroy and recreate the resource.",
@@ -241,47 +569,179 @@ func (r *ExternalNetworkResource) Delete(ctx context.Context, req resource.Delet
 		return
 	}
 
-	tflog.Debug(ctx, "Deleting external network", map[string]interface{}{"id": data.ID.ValueString()})
+	clusterID := int(data.ClusterID.ValueInt64())
+	networkID, err := strconv.ParseInt(data.ID.ValueString(), 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid external network ID",
+			fmt.Sprintf("Unable to parse external network ID '%s': %s", data.ID.ValueString(), err),
+		)
+		return
+	}
 
-	// 注意：SDK 中没有提供删除外部网络的 API，这里只记录日志
-	tflog.Warn(ctx, "External network deletion is not implemented in SDK")
-	resp.Diagnostics.AddWarning(
-		"Delete not implemented",
-		"The SDK does not provide an API to delete external networks. The resource will be removed from state only.",
-	)
+	// 先确认外部网络仍然存在，之后 DELETE 返回的错误都不能视为已删除
+	network, err := findExternalNetwork(r.client, clusterID, networkID)
+	if err != nil && !isNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Failed to read external network",
+			fmt.Sprintf("Unable to query external network %d in cluster %d, got error: %s", networkID, clusterID, err),
+		)
+		return
+	}
+	if network == nil {
+		tflog.Warn(ctx, "External network already deleted", map[string]interface{}{"id": networkID})
+		return
+	}
+
+	// IP 池中仍有已分配的 IP 时，删除会导致正在使用的服务失去地址，直接报错
+	pools, err := listExternalNetworkIpPools(r.client, clusterID, networkID)
+	if err != nil {
+		if isNotFoundError(err) {
+			tflog.Warn(ctx, "External network already deleted", map[string]interface{}{"id": networkID})
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Failed to query external network IP pools",
+			fmt.Sprintf("Unable to query IP pools of external network %d, got error: %s", networkID, err),
+		)
+		return
+	}
+
+	var usedPools []string
+	for _, pool := range pools {
+		if pool.ExistIpUsed {
+			usedPools = append(usedPools, fmt.Sprintf("%s (%d/%d IPs used)", pool.Name, pool.IpUsedNum, pool.IpTotalNum))
+		}
+	}
+	if len(usedPools) > 0 {
+		resp.Diagnostics.AddError(
+			"External network is in use",
+			fmt.Sprintf("External network %d (%s) cannot be deleted because IPs are still allocated from its IP pools: %s. "+
+				"Release the IPs (delete the services or pods using them) and try again.",
+				networkID, data.Name.ValueString(), strings.Join(usedPools, ", ")),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Deleting external network", map[string]interface{}{
+		"cluster_id": clusterID,
+		"id":         networkID,
+	})
+
+	// 外部网络已确认存在，DELETE 返回的任何错误（包括 404）都要报告，否则会在网络仍存在时从 state 中移除
+	err = deleteExternalNetwork(r.client, clusterID, networkID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to delete external network",
+			fmt.Sprintf("Unable to delete external network %d from cluster %d, got error: %s", networkID, clusterID, err),
+		)
+		return
+	}
+
+	if err := waitForExternalNetworkDeleted(ctx, r.client, clusterID, networkID); err != nil {
+		resp.Diagnostics.AddError("Failed to delete external network", err.Error())
+		return
+	}
+
+	tflog.Trace(ctx, "Deleted external network", map[string]interface{}{"id": networkID})
 }
 
 func (r *ExternalNetworkResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	// 按 ID 查询需要集群 ID，导入 ID 格式为 <cluster_id>/<id>
+	clusterIDStr, networkIDStr, ok := strings.Cut(req.ID, "/")
+	clusterID, clusterErr := strconv.ParseInt(clusterIDStr, 10, 64)
+	_, networkErr := strconv.ParseInt(networkIDStr, 10, 64)
+	if !ok || clusterErr != nil || networkErr != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing external network ID",
+			fmt.Sprintf("Unable to parse import ID '%s'. Expected format: <cluster_id>/<id>", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), networkIDStr)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cluster_id"), clusterID)...)
 }
 
 // ErrResourceNotFound 资源未找到错误
 var ErrResourceNotFound = errors.New("resource not found")
 
-// readExternalNetwork 读取外部网络详情
+// readExternalNetwork 读取外部网络详情。已知 ID 时按 ID 查询，
+// 创建后尚无 ID 时按名称（及网卡）查询。
 func (r *ExternalNetworkResource) readExternalNetwork(ctx context.Context, data *ExternalNetworkResourceModel) error {
 	clusterId := int(data.ClusterID.ValueInt64())
 
-	// 查询外部网络列表
-	queryParam := param.NewQueryParam()
-	queryParam.AddQ("name=" + data.Name.ValueString())
+	var network *view.ExternalNetworkView
+	if !data.ID.IsNull() && !data.ID.IsUnknown() && data.ID.ValueString() != "" {
+		networkID, err := strconv.ParseInt(data.ID.ValueString(), 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid external network ID %q", data.ID.ValueString())
+		}
 
-	networks, _, err := r.client.PageExternalNetwork(clusterId, queryParam)
-	if err != nil {
-		return fmt.Errorf("failed to query external network: %w", err)
+		network, err = findExternalNetwork(r.client, clusterId, networkID)
+		if isNotFoundError(err) {
+			return ErrResourceNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("failed to query external network: %w", err)
+		}
+	} else {
+		networks, err := listExternalNetworks(r.client, clusterId)
+		if isNotFoundError(err) {
+			return ErrResourceNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("failed to query external network: %w", err)
+		}
+		network = matchExternalNetwork(networks, data.Name.ValueString(), data.Interface.ValueString())
 	}
 
-	if len(networks) == 0 {
+	if network == nil {
 		return ErrResourceNotFound
 	}
 
-	// 使用第一个匹配的网络
-	network := networks[0]
+	tflog.Debug(ctx, "External network details retrieved", map[string]interface{}{
+		"id":                network.ID,
+		"spider_pool_ready": network.SpiderPoolReady,
+		"metallb_ready":     network.MetallbReady,
+	})
+
+	setExternalNetworkModel(data, network)
+	return nil
+}
+
+// matchExternalNetwork 从列表中选出名称相同的外部网络。
+// 名称在不同类型的网络间可能重复，优先选择网卡相同的，仍有多个时选择最新创建的。
+func matchExternalNetwork(networks []view.ExternalNetworkView, name, iface string) *view.ExternalNetworkView {
+	var match *view.ExternalNetworkView
+	for i := range networks {
+		network := &networks[i]
+		if network.Name != name {
+			continue
+		}
+		if match != nil {
+			sameIface, matchSameIface := network.Iface == iface, match.Iface == iface
+			if matchSameIface && !sameIface {
+				continue
+			}
+			if matchSameIface == sameIface && !network.CreateTime.After(match.CreateTime) {
+				continue
+			}
+		}
+		match = network
+	}
+	return match
+}
 
+// setExternalNetworkModel 将平台返回的外部网络写入资源模型
+func setExternalNetworkModel(data *ExternalNetworkResourceModel, network *view.ExternalNetworkView) {
 	data.ID = types.StringValue(strconv.FormatInt(network.ID, 10))
 	data.ClusterID = types.Int64Value(network.ClusterID)
 	data.Name = types.StringValue(network.Name)
-	data.Description = types.StringValue(network.Description)
+	// 未设置描述时平台返回空字符串，保持 null 避免产生差异
+	if network.Description != "" || !data.Description.IsNull() {
+		data.Description = types.StringValue(network.Description)
+	}
 	data.Gateway = types.StringValue(network.Gateway)
 	data.Netmask = types.StringValue(network.Netmask)
 	data.Interface = types.StringValue(network.Iface)
@@ -293,9 +753,98 @@ func (r *ExternalNetworkResource) readExternalNetwork(ctx context.Context, data
 		data.Status = types.StringValue("Inactive")
 	}
 
+	data.Type = types.StringValue(string(network.Type))
+	// 与计划中的值保持一致，cidr 由网关和子网掩码计算
+	if prefix, ok := networkPrefix(network); ok {
+		data.Cidr = types.StringValue(prefix.String())
+	} else {
+		data.Cidr = types.StringValue(network.Cidr)
+	}
+	data.IpTotalNum = types.Int64Value(network.IpTotalNum)
+	data.IpUsedNum = types.Int64Value(network.IpUsedNum)
+	data.SpiderPoolReady = types.BoolValue(network.SpiderPoolReady)
+	data.MetallbReady = types.BoolValue(network.MetallbReady)
+
 	data.CreateTime = types.StringValue(network.CreateTime.Format("2006-01-02 15:04:05"))
-	// SDK 新版本没有 UpdateTime，使用 CreateTime
+	// 平台不返回更新时间，使用 CreateTime（update_time 已弃用）
 	data.UpdateTime = types.StringValue(network.CreateTime.Format("2006-01-02 15:04:05"))
+}
 
-	return nil
+// clusterCIDR 集群配置中的 Pod 或 Service 网段
+type clusterCIDR struct {
+	name  string
+	value string
+}
+
+// clusterCIDRs 从集群详情的配置中取出 Pod 和 Service 网段（如 podCidrV4、serviceCIDR），
+// 双栈配置以逗号分隔
+func clusterCIDRs(config map[string]interface{}) []clusterCIDR {
+	var cidrs []clusterCIDR
+	for _, key := range slices.Sorted(maps.Keys(config)) {
+		name := strings.TrimSuffix(strings.ToLower(key), "v4")
+		if name != "podcidr" && name != "servicecidr" {
+			continue
+		}
+		value, ok := config[key].(string)
+		if !ok {
+			continue
+		}
+		for _, cidr := range strings.Split(value, ",") {
+			if cidr = strings.TrimSpace(cidr); cidr != "" {
+				cidrs = append(cidrs, clusterCIDR{name: strings.TrimSuffix(name, "cidr"), value: cidr})
+			}
+		}
+	}
+	return cidrs
+}
+
+// configuredPrefix 返回配置中的网段：优先使用 cidr，否则由 gateway 和 netmask 计算
+func configuredPrefix(config *ExternalNetworkResourceModel) (netip.Prefix, bool) {
+	if !config.Cidr.IsNull() {
+		if config.Cidr.IsUnknown() {
+			return netip.Prefix{}, false
+		}
+		prefix, err := netip.ParsePrefix(config.Cidr.ValueString())
+		return prefix.Masked(), err == nil
+	}
+
+	if config.Gateway.IsUnknown() || config.Netmask.IsUnknown() {
+		return netip.Prefix{}, false
+	}
+	return externalNetworkPrefix(config.Gateway.ValueString(), config.Netmask.ValueString())
+}
+
+// networkPrefix 返回平台中外部网络的网段
+func networkPrefix(network *view.ExternalNetworkView) (netip.Prefix, bool) {
+	if prefix, ok := externalNetworkPrefix(network.Gateway, network.Netmask); ok {
+		return prefix, true
+	}
+	prefix, err := netip.ParsePrefix(network.Cidr)
+	return prefix.Masked(), err == nil
+}
+
+// externalNetworkPrefix 由网关和子网掩码计算网段
+func externalNetworkPrefix(gateway, netmask string) (netip.Prefix, bool) {
+	addr, err := netip.ParseAddr(gateway)
+	if err != nil || !addr.Is4() {
+		return netip.Prefix{}, false
+	}
+	bits, ok := netmaskBits(netmask)
+	if !ok {
+		return netip.Prefix{}, false
+	}
+	return netip.PrefixFrom(addr, bits).Masked(), true
+}
+
+// prefixNetmask 将前缀长度转换为点分十进制子网掩码
+func prefixNetmask(bits int) string {
+	return net.IP(net.CIDRMask(bits, 32)).String()
+}
+
+// prefixBroadcast 返回 IPv4 网段的广播地址
+func prefixBroadcast(prefix netip.Prefix) netip.Addr {
+	b := prefix.Masked().Addr().As4()
+	value := binary.BigEndian.Uint32(b[:]) | uint32(uint64(1)<<(32-prefix.Bits())-1)
+	binary.BigEndian.PutUint32(b[:], value)
+	return netip.AddrFrom4(b)
 }
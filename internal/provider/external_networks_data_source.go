@@ -6,15 +6,32 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"zstack.io/edge-go-sdk/pkg/client"
 	"zstack.io/edge-go-sdk/pkg/param"
+	"zstack.io/edge-go-sdk/pkg/view"
 )
 
+// externalNetworkFilterFields filter 块可以使用的字段，平台只支持按 ID 和名称查询
+var externalNetworkFilterFields = map[string]queryFilterField[view.ExternalNetworkView]{
+	"id":                {query: "id", value: func(n view.ExternalNetworkView) string { return strconv.FormatInt(n.ID, 10) }},
+	"name":              {query: "name", value: func(n view.ExternalNetworkView) string { return n.Name }},
+	"interface":         {value: func(n view.ExternalNetworkView) string { return n.Iface }},
+	"type":              {value: func(n view.ExternalNetworkView) string { return string(n.Type) }},
+	"gateway":           {value: func(n view.ExternalNetworkView) string { return n.Gateway }},
+	"ip_total_num":      {value: func(n view.ExternalNetworkView) string { return strconv.FormatInt(n.IpTotalNum, 10) }},
+	"ip_used_num":       {value: func(n view.ExternalNetworkView) string { return strconv.FormatInt(n.IpUsedNum, 10) }},
+	"spider_pool_ready": {value: func(n view.ExternalNetworkView) string { return strconv.FormatBool(n.SpiderPoolReady) }},
+	"metallb_ready":     {value: func(n view.ExternalNetworkView) string { return strconv.FormatBool(n.MetallbReady) }},
+}
+
 var _ datasource.DataSource = &ExternalNetworksDataSource{}
 
 func NewExternalNetworksDataSource() datasource.DataSource {
@@ -29,19 +46,26 @@ type ExternalNetworksDataSourceModel struct {
 	ClusterID types.Int64                      `tfsdk:"cluster_id"`
 	Name      types.String                     `tfsdk:"name"`
 	Networks  []ExternalNetworkDataSourceModel `tfsdk:"networks"`
+	Filter    []QueryFilterModel               `tfsdk:"filter"`
 }
 
 type ExternalNetworkDataSourceModel struct {
-	ID          types.Int64  `tfsdk:"id"`
-	ClusterID   types.Int64  `tfsdk:"cluster_id"`
-	Name        types.String `tfsdk:"name"`
-	Description types.String `tfsdk:"description"`
-	Gateway     types.String `tfsdk:"gateway"`
-	Netmask     types.String `tfsdk:"netmask"`
-	Interface   types.String `tfsdk:"interface"`
-	Status      types.String `tfsdk:"status"`
-	CreateTime  types.String `tfsdk:"create_time"`
-	UpdateTime  types.String `tfsdk:"update_time"`
+	ID              types.Int64  `tfsdk:"id"`
+	ClusterID       types.Int64  `tfsdk:"cluster_id"`
+	Name            types.String `tfsdk:"name"`
+	Description     types.String `tfsdk:"description"`
+	Gateway         types.String `tfsdk:"gateway"`
+	Netmask         types.String `tfsdk:"netmask"`
+	Interface       types.String `tfsdk:"interface"`
+	Status          types.String `tfsdk:"status"`
+	Type            types.String `tfsdk:"type"`
+	Cidr            types.String `tfsdk:"cidr"`
+	IpTotalNum      types.Int64  `tfsdk:"ip_total_num"`
+	IpUsedNum       types.Int64  `tfsdk:"ip_used_num"`
+	SpiderPoolReady types.Bool   `tfsdk:"spider_pool_ready"`
+	MetallbReady    types.Bool   `tfsdk:"metallb_ready"`
+	CreateTime      types.String `tfsdk:"create_time"`
+	UpdateTime      types.String `tfsdk:"update_time"`
 }
 
 func (d *ExternalNetworksDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -98,18 +122,47 @@ func (d *ExternalNetworksDataSource) Schema(ctx context.Context, req datasource.
 							MarkdownDescription: "外部网络状态",
 							Computed:            true,
 						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "网络类型：`manager`（管理网络）或 `business`（业务网络）",
+							Computed:            true,
+						},
+						"cidr": schema.StringAttribute{
+							MarkdownDescription: "外部网络的 IPv4 CIDR",
+							Computed:            true,
+						},
+						"ip_total_num": schema.Int64Attribute{
+							MarkdownDescription: "IP 池中的 IP 总数",
+							Computed:            true,
+						},
+						"ip_used_num": schema.Int64Attribute{
+							MarkdownDescription: "已使用的 IP 数量",
+							Computed:            true,
+						},
+						"spider_pool_ready": schema.BoolAttribute{
+							MarkdownDescription: "SpiderPool（容器组附加网络）是否就绪",
+							Computed:            true,
+						},
+						"metallb_ready": schema.BoolAttribute{
+							MarkdownDescription: "MetalLB（服务负载均衡）是否就绪",
+							Computed:            true,
+						},
 						"create_time": schema.StringAttribute{
 							MarkdownDescription: "创建时间",
 							Computed:            true,
 						},
 						"update_time": schema.StringAttribute{
-							MarkdownDescription: "更新时间",
+							MarkdownDescription: "更新时间。平台不返回更新时间，该值始终与 `create_time` 相同",
 							Computed:            true,
+							DeprecationMessage:  "The platform does not report an update time; this attribute always equals create_time and will be removed in a future version.",
 						},
 					},
 				},
 			},
 		},
+
+		Blocks: map[string]schema.Block{
+			"filter": queryFilterBlock(externalNetworkFilterFields),
+		},
 	}
 }
 
@@ -143,13 +196,22 @@ func (d *ExternalNetworksDataSource) Read(ctx context.Context, req datasource.Re
 	if !data.Name.IsNull() {
 		queryParam.AddQ("name=" + data.Name.ValueString())
 	}
+	match, err := applyQueryFilters(data.Filter, externalNetworkFilterFields, &queryParam)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("filter"), "Invalid filter", err.Error())
+		return
+	}
 
-	// 查询外部网络列表
-	networks, _, err := d.client.PageExternalNetwork(int(data.ClusterID.ValueInt64()), queryParam)
+	// 查询外部网络列表（自动分页）
+	clusterID := int(data.ClusterID.ValueInt64())
+	networks, _, err := pageAll(queryParam, 0, func(queryParam param.QueryParam) ([]view.ExternalNetworkView, int, error) {
+		return d.client.PageExternalNetwork(clusterID, queryParam)
+	})
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to query external networks", err.Error())
 		return
 	}
+	networks = slices.DeleteFunc(networks, func(network view.ExternalNetworkView) bool { return !match(network) })
 
 	// 转换数据
 	data.Networks = make([]ExternalNetworkDataSourceModel, 0, len(networks))
@@ -160,17 +222,28 @@ func (d *ExternalNetworksDataSource) Read(ctx context.Context, req datasource.Re
 			status = "Active"
 		}
 
+		cidr := network.Cidr
+		if prefix, ok := networkPrefix(&network); ok {
+			cidr = prefix.String()
+		}
+
 		networkModel := ExternalNetworkDataSourceModel{
-			ID:          types.Int64Value(network.ID),
-			ClusterID:   types.Int64Value(network.ClusterID),
-			Name:        types.StringValue(network.Name),
-			Description: types.StringValue(network.Description),
-			Gateway:     types.StringValue(network.Gateway),
-			Netmask:     types.StringValue(network.Netmask),
-			Interface:   types.StringValue(network.Iface),
-			Status:      types.StringValue(status),
-			CreateTime:  types.StringValue(network.CreateTime.Format("2006-01-02 15:04:05")),
-			// SDK 新版本没有 UpdateTime，使用 CreateTime
+			ID:              types.Int64Value(network.ID),
+			ClusterID:       types.Int64Value(network.ClusterID),
+			Name:            types.StringValue(network.Name),
+			Description:     types.StringValue(network.Description),
+			Gateway:         types.StringValue(network.Gateway),
+			Netmask:         types.StringValue(network.Netmask),
+			Interface:       types.StringValue(network.Iface),
+			Status:          types.StringValue(status),
+			Type:            types.StringValue(string(network.Type)),
+			Cidr:            types.StringValue(cidr),
+			IpTotalNum:      types.Int64Value(network.IpTotalNum),
+			IpUsedNum:       types.Int64Value(network.IpUsedNum),
+			SpiderPoolReady: types.BoolValue(network.SpiderPoolReady),
+			MetallbReady:    types.BoolValue(network.MetallbReady),
+			CreateTime:      types.StringValue(network.CreateTime.Format("2006-01-02 15:04:05")),
+			// 平台不返回更新时间，使用 CreateTime（update_time 已弃用）
 			UpdateTime: types.StringValue(network.CreateTime.Format("2006-01-02 15:04:05")),
 		}
 		data.Networks = append(data.Networks, networkModel)
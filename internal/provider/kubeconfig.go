@@ -0,0 +1,145 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"gopkg.in/yaml.v2"
+
+	"zstack.io/edge-go-sdk/pkg/client"
+)
+
+// kubeconfigCredentials 从 kubeconfig 中解析出的连接信息，
+// 可直接用于 kubernetes / helm provider 的配置
+type kubeconfigCredentials struct {
+	Host                 string
+	ClusterCACertificate string
+	ClientCertificate    string
+	ClientKey            string
+	Token                string
+}
+
+// kubeconfigFile kubeconfig 文件中需要用到的字段
+type kubeconfigFile struct {
+	CurrentContext string `yaml:"current-context"`
+	Clusters       []struct {
+		Name    string `yaml:"name"`
+		Cluster struct {
+			Server                   string `yaml:"server"`
+			CertificateAuthorityData string `yaml:"certificate-authority-data"`
+		} `yaml:"cluster"`
+	} `yaml:"clusters"`
+	Users []struct {
+		Name string `yaml:"name"`
+		User struct {
+			ClientCertificateData string `yaml:"client-certificate-data"`
+			ClientKeyData         string `yaml:"client-key-data"`
+			Token                 string `yaml:"token"`
+		} `yaml:"user"`
+	} `yaml:"users"`
+	Contexts []struct {
+		Name    string `yaml:"name"`
+		Context struct {
+			Cluster string `yaml:"cluster"`
+			User    string `yaml:"user"`
+		} `yaml:"context"`
+	} `yaml:"contexts"`
+}
+
+// readClusterKubeconfig 获取集群的原始 kubeconfig 并解析出连接信息
+func readClusterKubeconfig(zeClient *client.ZeClient, clusterID int) (string, *kubeconfigCredentials, error) {
+	config, err := zeClient.GetClusterKubeconfig(clusterID)
+	if err != nil {
+		return "", nil, fmt.Errorf("unable to get kubeconfig of cluster %d: %w", clusterID, err)
+	}
+
+	raw := config.Kubeconfig
+	if raw == "" {
+		raw = config.Config
+	}
+	if raw == "" {
+		return "", nil, fmt.Errorf("cluster %d returned an empty kubeconfig", clusterID)
+	}
+
+	creds, err := parseKubeconfig(raw)
+	if err != nil {
+		return "", nil, fmt.Errorf("unable to parse kubeconfig of cluster %d: %w", clusterID, err)
+	}
+
+	return raw, creds, nil
+}
+
+// parseKubeconfig 解析 kubeconfig，优先使用 current-context 指向的集群和用户，
+// 否则使用第一个集群和第一个用户。证书类字段会从 base64 解码为 PEM 格式。
+func parseKubeconfig(raw string) (*kubeconfigCredentials, error) {
+	var file kubeconfigFile
+	if err := yaml.Unmarshal([]byte(raw), &file); err != nil {
+		return nil, err
+	}
+
+	if len(file.Clusters) == 0 {
+		return nil, errors.New("kubeconfig contains no clusters")
+	}
+
+	clusterName, userName := "", ""
+	for _, c := range file.Contexts {
+		if c.Name == file.CurrentContext {
+			clusterName, userName = c.Context.Cluster, c.Context.User
+			break
+		}
+	}
+
+	clusterIdx := 0
+	for i, c := range file.Clusters {
+		if c.Name == clusterName {
+			clusterIdx = i
+			break
+		}
+	}
+	cluster := file.Clusters[clusterIdx].Cluster
+
+	creds := &kubeconfigCredentials{
+		Host: cluster.Server,
+	}
+
+	var err error
+	if creds.ClusterCACertificate, err = decodeKubeconfigData(cluster.CertificateAuthorityData); err != nil {
+		return nil, fmt.Errorf("invalid certificate-authority-data: %w", err)
+	}
+
+	if len(file.Users) > 0 {
+		userIdx := 0
+		for i, u := range file.Users {
+			if u.Name == userName {
+				userIdx = i
+				break
+			}
+		}
+		user := file.Users[userIdx].User
+
+		if creds.ClientCertificate, err = decodeKubeconfigData(user.ClientCertificateData); err != nil {
+			return nil, fmt.Errorf("invalid client-certificate-data: %w", err)
+		}
+		if creds.ClientKey, err = decodeKubeconfigData(user.ClientKeyData); err != nil {
+			return nil, fmt.Errorf("invalid client-key-data: %w", err)
+		}
+		creds.Token = user.Token
+	}
+
+	return creds, nil
+}
+
+func decodeKubeconfigData(data string) (string, error) {
+	if data == "" {
+		return "", nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return "", err
+	}
+	return string(decoded), nil
+}
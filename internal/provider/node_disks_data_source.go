@@ -0,0 +1,246 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"zstack.io/edge-go-sdk/pkg/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &NodeDisksDataSource{}
+
+func NewNodeDisksDataSource() datasource.DataSource {
+	return &NodeDisksDataSource{}
+}
+
+// NodeDisksDataSource defines the data source implementation.
+type NodeDisksDataSource struct {
+	client *client.ZeClient
+}
+
+// NodeDisksDataSourceModel describes the data source data model.
+type NodeDisksDataSourceModel struct {
+	Password         types.String         `tfsdk:"password"`
+	PasswordEncoding types.String         `tfsdk:"password_encoding"`
+	Hosts            []NodeDisksHostModel `tfsdk:"hosts"`
+	UnusedDisks      types.Map            `tfsdk:"unused_disks"`
+}
+
+// NodeDisksHostModel describes a host and its candidate disks.
+type NodeDisksHostModel struct {
+	Name  types.String        `tfsdk:"name"`
+	IP    types.String        `tfsdk:"ip"`
+	Port  types.Int64         `tfsdk:"port"`
+	Disks []NodeDiskInfoModel `tfsdk:"disks"`
+}
+
+// NodeDiskInfoModel describes a candidate disk.
+type NodeDiskInfoModel struct {
+	Name    types.String `tfsdk:"name"`
+	Path    types.String `tfsdk:"path"`
+	Size    types.Int64  `tfsdk:"size"`
+	SizeStr types.String `tfsdk:"size_str"`
+	Used    types.Bool   `tfsdk:"used"`
+}
+
+func (d *NodeDisksDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_node_disks"
+}
+
+func (d *NodeDisksDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "通过 SSH 查询主机的候选数据盘（非系统盘）。可用于计算 `zstack_cluster` / `zstack_node` 的 `data_disk`、`image_data_disk` 配置，无需登录每台主机执行 `lsblk`。",
+
+		Attributes: map[string]schema.Attribute{
+			"password": schema.StringAttribute{
+				MarkdownDescription: "主机 SSH 密码",
+				Required:            true,
+				Sensitive:           true,
+			},
+			"password_encoding": schema.StringAttribute{
+				MarkdownDescription: "密码编码方式：`plain`（明文，默认）或 `encrypted`（已使用 Provider 的 AccessKey Secret 加密）",
+				Optional:            true,
+				Validators: []validator.String{
+					stringOneOf(passwordEncodings...),
+				},
+			},
+			"hosts": schema.ListNestedAttribute{
+				MarkdownDescription: "要查询的主机列表",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "节点名称，用作 `unused_disks` 的键；未设置时使用 IP。各主机的键不能重复",
+							Optional:            true,
+						},
+						"ip": schema.StringAttribute{
+							MarkdownDescription: "主机 SSH IP 地址",
+							Required:            true,
+						},
+						"port": schema.Int64Attribute{
+							MarkdownDescription: "SSH 端口，默认为 22",
+							Optional:            true,
+						},
+						"disks": schema.ListNestedAttribute{
+							MarkdownDescription: "主机上的非系统盘",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"name": schema.StringAttribute{
+										MarkdownDescription: "磁盘名称",
+										Computed:            true,
+									},
+									"path": schema.StringAttribute{
+										MarkdownDescription: "磁盘设备路径，如 `/dev/sdb`，与 `unused_disks` 和 `data_disk` 使用的格式相同",
+										Computed:            true,
+									},
+									"size": schema.Int64Attribute{
+										MarkdownDescription: "磁盘大小（字节）",
+										Computed:            true,
+									},
+									"size_str": schema.StringAttribute{
+										MarkdownDescription: "磁盘大小（可读格式）",
+										Computed:            true,
+									},
+									"used": schema.BoolAttribute{
+										MarkdownDescription: "磁盘是否已被使用",
+										Computed:            true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"unused_disks": schema.MapAttribute{
+				MarkdownDescription: "各主机未使用的磁盘（节点名称 -> 磁盘设备路径列表，如 `/dev/sdb`），可直接用于 `data_disk`",
+				Computed:            true,
+				ElementType:         types.ListType{ElemType: types.StringType},
+			},
+		},
+	}
+}
+
+func (d *NodeDisksDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.ZeClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.ZeClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *NodeDisksDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data NodeDisksDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	password, err := prepareSSHPassword(d.client, data.Password.ValueString(), data.PasswordEncoding.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("password_encoding"), "Invalid SSH password", err.Error())
+		return
+	}
+
+	// unused_disks 以节点名称（未设置时为 IP）为键，键重复时后面的主机会覆盖前面的主机
+	hostIndexes := make(map[string]int, len(data.Hosts))
+	for i := range data.Hosts {
+		key := nodeDisksHostKey(&data.Hosts[i])
+		if j, ok := hostIndexes[key]; ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("hosts").AtListIndex(i),
+				"Duplicate host",
+				fmt.Sprintf("Hosts %d and %d both use %q as their unused_disks key. Set a unique name for each host.", j, i, key),
+			)
+			continue
+		}
+		hostIndexes[key] = i
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	unusedDisks := make(map[string][]string, len(data.Hosts))
+	for i := range data.Hosts {
+		host := &data.Hosts[i]
+
+		port := defaultSSHPort
+		if !host.Port.IsNull() {
+			port = int(host.Port.ValueInt64())
+		}
+
+		tflog.Debug(ctx, "Querying node disks", map[string]interface{}{
+			"ip":   host.IP.ValueString(),
+			"port": port,
+		})
+
+		// 逐台查询，汇总所有主机的错误后统一返回
+		disks, err := d.client.GetNodeDisk(host.IP.ValueString(), port, password)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("hosts").AtListIndex(i),
+				"Failed to query node disks",
+				fmt.Sprintf("Unable to query disks of host %s:%d, got error: %s", host.IP.ValueString(), port, err),
+			)
+			continue
+		}
+
+		host.Disks = make([]NodeDiskInfoModel, 0, len(disks))
+		unused := make([]string, 0, len(disks))
+		for _, disk := range disks {
+			host.Disks = append(host.Disks, NodeDiskInfoModel{
+				Name:    types.StringValue(disk.Name),
+				Path:    types.StringValue(diskDevicePath(disk.Name)),
+				Size:    types.Int64Value(disk.Size),
+				SizeStr: types.StringValue(disk.SizeStr),
+				Used:    types.BoolValue(disk.Used),
+			})
+			if !disk.Used {
+				unused = append(unused, diskDevicePath(disk.Name))
+			}
+		}
+		unusedDisks[nodeDisksHostKey(host)] = unused
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	unusedDisksValue, diags := types.MapValueFrom(ctx, types.ListType{ElemType: types.StringType}, unusedDisks)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.UnusedDisks = unusedDisksValue
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// nodeDisksHostKey 返回主机在 unused_disks 中的键：节点名称，未设置时为 IP
+func nodeDisksHostKey(host *NodeDisksHostModel) string {
+	if !host.Name.IsNull() && host.Name.ValueString() != "" {
+		return host.Name.ValueString()
+	}
+	return host.IP.ValueString()
+}
@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"zstack.io/edge-go-sdk/pkg/client"
+	"zstack.io/edge-go-sdk/pkg/param"
+	"zstack.io/edge-go-sdk/pkg/util/httputils"
+	"zstack.io/edge-go-sdk/pkg/view"
+)
+
+// defaultSSHPort 未指定 SSH 端口时使用的默认值
+const defaultSSHPort = 22
+
+// listClusterNodes 分页查询集群中的全部节点
+func listClusterNodes(zeClient *client.ZeClient, clusterID int) ([]view.NodeView, error) {
+	nodes, _, err := pageAll(param.NewQueryParam(), 0, func(queryParam param.QueryParam) ([]view.NodeView, int, error) {
+		return zeClient.PageNode(clusterID, queryParam)
+	})
+	return nodes, err
+}
+
+// isNotFoundError 判断 API 返回的错误是否表示资源不存在
+func isNotFoundError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var clientErr *httputils.JSONClientError
+	if errors.As(err, &clientErr) && clientErr.Code == http.StatusNotFound {
+		return true
+	}
+
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "not found") || strings.Contains(msg, "not exist")
+}
+
+// isNodeStatusFailed 判断节点是否处于失败状态
+func isNodeStatusFailed(status string) bool {
+	return strings.Contains(strings.ToLower(status), "fail")
+}
+
+// parseNodeRoles 将平台返回的节点角色（逗号分隔）拆分为角色列表
+func parseNodeRoles(role string) []string {
+	roles := make([]string, 0)
+	for _, r := range strings.Split(role, ",") {
+		if r = strings.TrimSpace(r); r != "" {
+			roles = append(roles, r)
+		}
+	}
+	return roles
+}
+
+// diskDevicePath 将磁盘名称统一为 /dev/xxx 形式的设备路径
+func diskDevicePath(name string) string {
+	if strings.HasPrefix(name, "/") {
+		return name
+	}
+	return "/dev/" + name
+}
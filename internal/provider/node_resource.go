@@ -6,22 +6,58 @@ package provider
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"zstack.io/edge-go-sdk/pkg/client"
 	"zstack.io/edge-go-sdk/pkg/param"
+	"zstack.io/edge-go-sdk/pkg/view"
+)
+
+const (
+	// nodeChangePolicyError 修改已有节点的 IP、角色时报错
+	nodeChangePolicyError = "error"
+	// nodeChangePolicyReplace 修改已有节点的 IP、角色时删除并重新添加该节点
+	nodeChangePolicyReplace = "replace"
+)
+
+var (
+	// containerRuntimes container_runtime 的可选值
+	containerRuntimes = []string{string(param.ContainerRunTimeContainerd), string(param.ContainerRunTimeDocker)}
+	// nodeRoles 节点角色的可选值
+	nodeRoles = []string{string(param.NodeRoleMaster), string(param.NodeRoleWorker), string(param.NodeRoleGPU)}
+	// gpuProducts gpu_product 的可选值
+	gpuProducts = []string{
+		string(param.GPUProductAscend),
+		string(param.GPUProductNvidia),
+		string(param.GPUProductIluvatar),
+		string(param.GPUProductHygon),
+		string(param.GPUProductEnflame),
+	}
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &NodeResource{}
 var _ resource.ResourceWithImportState = &NodeResource{}
+var _ resource.ResourceWithValidateConfig = &NodeResource{}
+var _ resource.ResourceWithModifyPlan = &NodeResource{}
+var _ resource.ResourceWithUpgradeState = &NodeResource{}
 
 func NewNodeResource() resource.Resource {
 	return &NodeResource{}
@@ -37,11 +73,18 @@ type NodeResourceModel struct {
 	ID               types.String `tfsdk:"id"`
 	ClusterID        types.Int64  `tfsdk:"cluster_id"`
 	Password         types.String `tfsdk:"password"`
+	PasswordWO       types.String `tfsdk:"password_wo"`
+	PasswordWOVer    types.Int64  `tfsdk:"password_wo_version"`
+	PasswordEncoding types.String `tfsdk:"password_encoding"`
 	ContainerRuntime types.String `tfsdk:"container_runtime"`
 	DNSServer        types.String `tfsdk:"dns_server"`
 	IluvatarLicense  types.String `tfsdk:"iluvatar_license"`
+	IluvatarLicWO    types.String `tfsdk:"iluvatar_license_wo"`
+	IluvatarLicWOVer types.Int64  `tfsdk:"iluvatar_license_wo_version"`
 	Nodes            types.List   `tfsdk:"nodes"` // []NodeAddModel
 	ImageDataDisk    types.Map    `tfsdk:"image_data_disk"`
+	Preflight        types.Bool   `tfsdk:"preflight"`
+	NodeChangePolicy types.String `tfsdk:"node_change_policy"`
 }
 
 // NodeAddModel describes the node add data model.
@@ -53,6 +96,7 @@ type NodeAddModel struct {
 	Port       types.Int64  `tfsdk:"port"`
 	Roles      types.List   `tfsdk:"roles"` // []string
 	GPUProduct types.String `tfsdk:"gpu_product"`
+	Status     types.String `tfsdk:"status"`
 }
 
 func (r *NodeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -61,11 +105,13 @@ func (r *NodeResource) Metadata(ctx context.Context, req resource.MetadataReques
 
 func (r *NodeResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "管理 ZStack Edge 集群节点资源。提供节点的添加和删除功能。",
+		MarkdownDescription: "管理 ZStack Edge 集群节点资源。提供节点的添加和删除功能，修改 `nodes` 时按节点名称只添加新增的节点、只删除被移除的节点。" +
+			"支持通过 `<cluster_id>:<name1>,<name2>` 导入指定节点，或通过 `<cluster_id>:*` 导入集群中全部非 Master 节点。",
+		Version: 1,
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				MarkdownDescription: "节点资源唯一标识符（节点名称列表）",
+				MarkdownDescription: "节点资源唯一标识符，格式为 `<cluster_id>:<name1>,<name2>`",
 				Computed:            true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
@@ -76,20 +122,55 @@ func (r *NodeResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Required:            true,
 			},
 			"password": schema.StringAttribute{
-				MarkdownDescription: "节点 SSH 密码",
-				Required:            true,
+				MarkdownDescription: "节点 SSH 密码，默认为明文，已加密的密码需配合 `password_encoding = \"encrypted\"` 使用。会保存在 state 中，推荐使用 `password_wo`。与 `password_wo` 二选一",
+				Optional:            true,
 				Sensitive:           true,
 			},
+			"password_wo": schema.StringAttribute{
+				MarkdownDescription: "节点 SSH 密码（write-only，需要 Terraform 1.11+），不会写入 plan 和 state。与 `password` 二选一",
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+			},
+			"password_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "`password_wo` 的版本号。修改 `password_wo` 后需同时修改该值，新密码才会在后续添加节点时生效",
+				Optional:            true,
+			},
+			"password_encoding": schema.StringAttribute{
+				MarkdownDescription: "密码编码方式：`plain`（明文，默认）或 `encrypted`（已使用 Provider 的 AccessKey Secret 加密）。Provider 提交前总会加密一次密码，`encrypted` 时会先解密，避免重复加密",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(passwordEncodingPlain),
+				Validators: []validator.String{
+					stringOneOf(passwordEncodings...),
+				},
+			},
 			"container_runtime": schema.StringAttribute{
-				MarkdownDescription: "容器运行时（containerd 或 docker）",
+				MarkdownDescription: "容器运行时（containerd 或 docker），默认为 containerd",
 				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(string(param.ContainerRunTimeContainerd)),
+				Validators: []validator.String{
+					stringOneOf(containerRuntimes...),
+				},
 			},
 			"dns_server": schema.StringAttribute{
 				MarkdownDescription: "DNS 服务器地址",
 				Optional:            true,
 			},
 			"iluvatar_license": schema.StringAttribute{
-				MarkdownDescription: "天数 GPU License",
+				MarkdownDescription: "天数 GPU License。会保存在 state 中，推荐使用 `iluvatar_license_wo`",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"iluvatar_license_wo": schema.StringAttribute{
+				MarkdownDescription: "天数 GPU License（write-only，需要 Terraform 1.11+），不会写入 plan 和 state。与 `iluvatar_license` 二选一",
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+			},
+			"iluvatar_license_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "`iluvatar_license_wo` 的版本号。修改 `iluvatar_license_wo` 后需同时修改该值，新 License 才会在后续添加节点时生效",
 				Optional:            true,
 			},
 			"nodes": schema.ListNestedAttribute{
@@ -102,29 +183,53 @@ func (r *NodeResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 							Required:            true,
 						},
 						"ip": schema.StringAttribute{
-							MarkdownDescription: "管理网络 IP 地址",
+							MarkdownDescription: "管理网络 IPv4 地址",
 							Required:            true,
+							Validators: []validator.String{
+								ipv4Address(),
+							},
 						},
 						"business_ip": schema.StringAttribute{
-							MarkdownDescription: "业务网络 IP 地址",
+							MarkdownDescription: "业务网络 IPv4 地址",
 							Optional:            true,
+							Validators: []validator.String{
+								ipv4Address(),
+							},
 						},
 						"ip6": schema.StringAttribute{
 							MarkdownDescription: "IPv6 地址",
 							Optional:            true,
+							Validators: []validator.String{
+								ipv6Address(),
+							},
 						},
 						"port": schema.Int64Attribute{
-							MarkdownDescription: "SSH 端口",
-							Required:            true,
+							MarkdownDescription: "SSH 端口，默认为 22",
+							Optional:            true,
+							Computed:            true,
+							Default:             int64default.StaticInt64(defaultSSHPort),
+							Validators: []validator.Int64{
+								int64Between(1, 65535),
+							},
 						},
 						"roles": schema.ListAttribute{
-							MarkdownDescription: "节点角色列表（Master, Worker, GPU）",
+							MarkdownDescription: "节点角色列表（Master, Worker, GPU）。包含 GPU 角色时必须设置 `gpu_product`",
 							Required:            true,
 							ElementType:         types.StringType,
+							Validators: []validator.List{
+								stringListOneOf(nodeRoles...),
+							},
 						},
 						"gpu_product": schema.StringAttribute{
 							MarkdownDescription: "GPU 产品类型（Ascend, Nvidia, Iluvatar, Hygon, Enflame）",
 							Optional:            true,
+							Validators: []validator.String{
+								stringOneOf(gpuProducts...),
+							},
+						},
+						"status": schema.StringAttribute{
+							MarkdownDescription: "节点状态",
+							Computed:            true,
 						},
 					},
 				},
@@ -134,6 +239,21 @@ func (r *NodeResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Optional:            true,
 				ElementType:         types.ListType{ElemType: types.StringType},
 			},
+			"preflight": schema.BoolAttribute{
+				MarkdownDescription: "是否在添加节点前执行预检，默认为 `false`。开启后会在 plan（以及 apply 开始）时通过 SSH 登录每个节点，" +
+					"检查 SSH 凭据、`image_data_disk` 中的磁盘是否存在且未被使用，以及添加天数 GPU 节点时集群是否已有 License，并一次性报告所有问题",
+				Optional: true,
+			},
+			"node_change_policy": schema.StringAttribute{
+				MarkdownDescription: "修改已有节点的 IP、角色或 GPU 类型时的处理方式：`error`（默认，报错）或 `replace`（从集群中删除该节点后重新添加）。" +
+					"新增、移除节点总是只影响对应的节点",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(nodeChangePolicyError),
+				Validators: []validator.String{
+					stringOneOf(nodeChangePolicyError, nodeChangePolicyReplace),
+				},
+			},
 		},
 	}
 }
@@ -155,96 +275,312 @@ func (r *NodeResource) Configure(ctx context.Context, req resource.ConfigureRequ
 	r.client = client
 }
 
-func (r *NodeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var data NodeResourceModel
+func (r *NodeResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	validateWriteOnlySecret(ctx, req.Config, "password", "password_wo", true, &resp.Diagnostics)
+	validateWriteOnlySecret(ctx, req.Config, "iluvatar_license", "iluvatar_license_wo", false, &resp.Diagnostics)
+
+	var nodesValue types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("nodes"), &nodesValue)...)
+	if resp.Diagnostics.HasError() || nodesValue.IsNull() || nodesValue.IsUnknown() {
+		return
+	}
+
+	var nodes []NodeAddModel
+	resp.Diagnostics.Append(nodesValue.ElementsAs(ctx, &nodes, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	names := make(map[string]int, len(nodes))
+	ips := make(map[string]int, len(nodes))
+	for i, node := range nodes {
+		nodePath := path.Root("nodes").AtListIndex(i)
+
+		// GPU 角色必须指定 GPU 类型
+		if !node.Roles.IsUnknown() && node.GPUProduct.IsNull() &&
+			slices.Contains(node.Roles.Elements(), attr.Value(types.StringValue(string(param.NodeRoleGPU)))) {
+			resp.Diagnostics.AddAttributeError(
+				nodePath.AtName("gpu_product"),
+				"Missing GPU product",
+				fmt.Sprintf("Node %q has the %s role, so gpu_product must be set.", node.Name.ValueString(), param.NodeRoleGPU),
+			)
+		}
 
+		if !node.Roles.IsNull() && !node.Roles.IsUnknown() && len(node.Roles.Elements()) == 0 {
+			resp.Diagnostics.AddAttributeError(
+				nodePath.AtName("roles"),
+				"Missing node roles",
+				fmt.Sprintf("Node %q must have at least one role.", node.Name.ValueString()),
+			)
+		}
+
+		// 节点名称和 IP 不能重复
+		if !node.Name.IsUnknown() {
+			if j, ok := names[node.Name.ValueString()]; ok {
+				resp.Diagnostics.AddAttributeError(
+					nodePath.AtName("name"),
+					"Duplicate node name",
+					fmt.Sprintf("Node name %q is already used by nodes[%d].", node.Name.ValueString(), j),
+				)
+			} else {
+				names[node.Name.ValueString()] = i
+			}
+		}
+		for _, ip := range []types.String{node.IP, node.BusinessIP, node.IP6} {
+			if ip.IsNull() || ip.IsUnknown() {
+				continue
+			}
+			if j, ok := ips[ip.ValueString()]; ok && j != i {
+				resp.Diagnostics.AddAttributeError(
+					nodePath,
+					"Duplicate node IP",
+					fmt.Sprintf("IP address %s of node %q is already used by nodes[%d].", ip.ValueString(), node.Name.ValueString(), j),
+				)
+			} else {
+				ips[ip.ValueString()] = i
+			}
+		}
+	}
+}
+
+func (r *NodeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var data NodeResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// 解析节点列表
-	var nodes []NodeAddModel
-	resp.Diagnostics.Append(data.Nodes.ElementsAs(ctx, &nodes, false)...)
+	// 添加节点时检查全部节点
+	if req.State.Raw.IsNull() {
+		if !data.ClusterID.IsUnknown() && !data.Nodes.IsUnknown() {
+			var nodes []NodeAddModel
+			resp.Diagnostics.Append(data.Nodes.ElementsAs(ctx, &nodes, false)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			r.checkMemberCollisions(int(data.ClusterID.ValueInt64()), nodes, nil, &resp.Diagnostics)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+		r.preflight(ctx, req, &data, nil, resp)
+		return
+	}
+
+	var state NodeResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// 构建添加节点参数
-	addParam := param.NodeAddParamOpenApi{
-		Password: data.Password.ValueString(),
-		NodeAddParam: param.NodeAddParam{
-			ClusterID:        data.ClusterID.ValueInt64(),
-			Nodes:            make([]param.NodeAddObjParam, 0, len(nodes)),
-			ContainerRuntime: param.ContainerRuntime(data.ContainerRuntime.ValueString()),
-			DNSServer:        data.DNSServer.ValueString(),
-			IluvatarLicense:  data.IluvatarLicense.ValueString(),
-		},
+	// 更换集群只能替换整个资源
+	if !data.ClusterID.Equal(state.ClusterID) {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("cluster_id"))
+		return
 	}
 
-	// 如果没有指定容器运行时，使用默认值
-	if addParam.ContainerRuntime == "" {
-		addParam.ContainerRuntime = param.ContainerRunTimeContainerd
+	// 节点列表变化时 ID 随之变化
+	if data.Nodes.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+		return
 	}
 
-	// 转换节点数据
-	nodeNames := make([]string, 0, len(nodes))
-	for _, node := range nodes {
-		var roles []string
-		resp.Diagnostics.Append(node.Roles.ElementsAs(ctx, &roles, false)...)
-		if resp.Diagnostics.HasError() {
+	var planNodes, stateNodes []NodeAddModel
+	resp.Diagnostics.Append(data.Nodes.ElementsAs(ctx, &planNodes, false)...)
+	resp.Diagnostics.Append(state.Nodes.ElementsAs(ctx, &stateNodes, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if names, known := nodeNamesOf(planNodes); known {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), nodeResourceID(data.ClusterID.ValueInt64(), names))...)
+	} else {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+	}
+
+	if data.NodeChangePolicy.IsUnknown() {
+		return
+	}
+
+	changes := diffNodes(stateNodes, planNodes)
+	if len(changes.changed) > 0 {
+		if data.NodeChangePolicy.ValueString() != nodeChangePolicyReplace {
+			changedNames := changes.changedNames()
+			for i, node := range planNodes {
+				if slices.Contains(changedNames, node.Name.ValueString()) {
+					resp.Diagnostics.AddAttributeError(
+						path.Root("nodes").AtListIndex(i),
+						"Node change not supported",
+						fmt.Sprintf("The IP addresses, roles or GPU product of existing node %q cannot be changed in place. "+
+							"Revert the change, rename the node, or set node_change_policy = \"replace\" to remove and re-add it.",
+							node.Name.ValueString()),
+					)
+				}
+			}
 			return
 		}
 
-		nodeRoles := make([]param.ClusterNodeRole, 0, len(roles))
-		for _, role := range roles {
-			nodeRoles = append(nodeRoles, param.ClusterNodeRole(role))
+		resp.Diagnostics.AddWarning(
+			"Nodes will be reinstalled",
+			fmt.Sprintf("Nodes %v will be removed from cluster %d and added again with the new settings, because node_change_policy is \"replace\".",
+				changes.changedNames(), data.ClusterID.ValueInt64()),
+		)
+	}
+
+	// 只检查本次新增（以及需要重装）的节点
+	if toAdd := changes.toAdd(); len(toAdd) > 0 {
+		r.checkMemberCollisions(int(data.ClusterID.ValueInt64()), toAdd, changes.toDelete(), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
 		}
 
-		nodeParam := param.NodeAddObjParam{
-			Name:       node.Name.ValueString(),
-			IP:         node.IP.ValueString(),
-			BusinessIp: node.BusinessIP.ValueString(),
-			IP6:        node.IP6.ValueString(),
-			Port:       int(node.Port.ValueInt64()),
-			Roles:      nodeRoles,
-			GPUProduct: param.GPUProduct(node.GPUProduct.ValueString()),
+		names := make(map[string]bool, len(toAdd))
+		for _, node := range toAdd {
+			names[node.Name.ValueString()] = true
 		}
-		addParam.Nodes = append(addParam.Nodes, nodeParam)
-		nodeNames = append(nodeNames, node.Name.ValueString())
+		r.preflight(ctx, req, &data, names, resp)
 	}
+}
 
-	// 解析镜像数据盘
+// preflight 在添加节点前检查 SSH 凭据、镜像数据盘和天数 GPU License。
+// onlyNodes 不为 nil 时只检查其中的节点。
+func (r *NodeResource) preflight(ctx context.Context, req resource.ModifyPlanRequest, data *NodeResourceModel, onlyNodes map[string]bool, resp *resource.ModifyPlanResponse) {
+	if !data.Preflight.ValueBool() {
+		return
+	}
+
+	// 配置中存在未知值时跳过，apply 时 Terraform 会用已知值再次 plan，届时执行检查
+	if !req.Config.Raw.IsFullyKnown() {
+		tflog.Debug(ctx, "Configuration contains unknown values, deferring node preflight checks to apply")
+		return
+	}
+
+	password, diags := resolveWriteOnlySecret(ctx, req.Config, data.Password, "password_wo")
+	resp.Diagnostics.Append(diags...)
+	iluvatarLicense, diags := resolveWriteOnlySecret(ctx, req.Config, data.IluvatarLicense, "iluvatar_license_wo")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	password, err := prepareSSHPassword(r.client, password, data.PasswordEncoding.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("password_encoding"), "Invalid SSH password", err.Error())
+		return
+	}
+
+	var nodes []NodeAddModel
+	var imageDataDisk map[string][]string
+	resp.Diagnostics.Append(data.Nodes.ElementsAs(ctx, &nodes, false)...)
 	if !data.ImageDataDisk.IsNull() {
-		imageDataDisk := make(map[string][]string)
-		for k, v := range data.ImageDataDisk.Elements() {
-			var disks []string
-			if listVal, ok := v.(types.List); ok {
-				resp.Diagnostics.Append(listVal.ElementsAs(ctx, &disks, false)...)
-				if resp.Diagnostics.HasError() {
-					return
-				}
-				imageDataDisk[k] = disks
-			}
+		resp.Diagnostics.Append(data.ImageDataDisk.ElementsAs(ctx, &imageDataDisk, false)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	checker := newPreflightChecker(r.client)
+
+	nodeNames := make(map[string]bool, len(nodes))
+	hosts := make([]preflightHost, 0, len(nodes))
+	var iluvatarNodes []string
+	for _, node := range nodes {
+		name := node.Name.ValueString()
+		nodeNames[name] = true
+		if onlyNodes != nil && !onlyNodes[name] {
+			continue
+		}
+		hosts = append(hosts, preflightHost{
+			name:  name,
+			ip:    node.IP.ValueString(),
+			port:  int(node.Port.ValueInt64()),
+			disks: imageDataDisk[name],
+		})
+		if node.GPUProduct.ValueString() == string(param.GPUProductIluvatar) {
+			iluvatarNodes = append(iluvatarNodes, name)
 		}
-		addParam.ImageDataDisk = imageDataDisk
 	}
 
-	tflog.Debug(ctx, "Adding nodes to cluster", map[string]interface{}{
-		"cluster_id": addParam.ClusterID,
-		"node_count": len(addParam.Nodes),
-		"node_names": nodeNames,
-	})
+	for _, name := range slices.Sorted(maps.Keys(imageDataDisk)) {
+		if !nodeNames[name] {
+			checker.addFinding("image_data_disk is configured for node %q, which is not in nodes", name)
+		}
+	}
+
+	// 未提供 License 时，集群中必须已经有 License
+	if iluvatarLicense == "" {
+		checker.checkIluvatarLicense(int(data.ClusterID.ValueInt64()), iluvatarNodes)
+	}
+
+	checker.checkHosts(ctx, password, hosts)
+	checker.report(&resp.Diagnostics)
+}
+
+func (r *NodeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data NodeResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	// 调用 SDK 添加节点（异步操作）
-	_, err := r.client.AddNode(int(data.ClusterID.ValueInt64()), addParam, false)
+	// 解析节点列表
+	var nodes []NodeAddModel
+	resp.Diagnostics.Append(data.Nodes.ElementsAs(ctx, &nodes, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nodeNames := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		nodeNames = append(nodeNames, node.Name.ValueString())
+	}
+
+	// 同一集群的成员变更串行执行，避免与其他资源或客户端的操作冲突
+	clusterID := int(data.ClusterID.ValueInt64())
+	unlock, err := lockCluster(ctx, r.client, clusterID)
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to add nodes", err.Error())
+		resp.Diagnostics.AddError(
+			"Failed to lock cluster",
+			fmt.Sprintf("Unable to start an operation on cluster %d: %s", clusterID, err),
+		)
 		return
 	}
+	defer unlock()
 
-	// 设置资源 ID（使用节点名称列表作为标识）
-	data.ID = types.StringValue(fmt.Sprintf("%v", nodeNames))
+	addErr := r.addNodes(ctx, req.Config, &data, nodes, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if addErr != nil {
+		missing, err := r.missingNodes(clusterID, nodes)
+		if err != nil || len(missing) == len(nodes) {
+			resp.Diagnostics.AddError(
+				"Failed to add nodes",
+				fmt.Sprintf("Unable to add nodes %v to cluster %d, got error: %s", nodeNames, clusterID, addErr),
+			)
+			return
+		}
+
+		// 部分节点已加入集群。返回错误会使资源被 taint，下次 apply 会删除并重新添加整批节点，
+		// 因此以警告报告失败的节点。保存的列表必须与计划一致（nodes 不是 computed 属性，
+		// 否则 Terraform 报告 inconsistent result 并同样 taint 资源）；下次 refresh 时
+		// 未加入的节点会被移除，apply 通过 Update 只重新添加这些节点。
+		resp.Diagnostics.Append(nodeJoinFailures(nodes, missing, clusterID, addErr, true)...)
+	}
+
+	r.syncNodes(ctx, &data, false, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// 设置资源 ID：<cluster_id>:<name1>,<name2>
+	data.setID(ctx, &resp.Diagnostics)
 
 	tflog.Trace(ctx, "Added nodes to cluster", map[string]interface{}{"node_names": nodeNames})
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -258,24 +594,126 @@ func (r *NodeResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	// 节点读取操作可以通过查询节点列表验证节点是否存在
-	// 这里简化处理，直接返回当前状态
+	// 集群已被删除时，节点随之消失
+	clusterID := int(data.ClusterID.ValueInt64())
+	if _, err := r.client.GetClusterDetails(clusterID); err != nil {
+		if isNotFoundError(err) {
+			tflog.Warn(ctx, "Cluster no longer exists, removing nodes from state", map[string]interface{}{
+				"cluster_id": clusterID,
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error reading cluster",
+			fmt.Sprintf("Unable to read cluster %d, got error: %s", clusterID, err),
+		)
+		return
+	}
+
+	// 平台上已不存在的节点从列表中移除，Terraform 会计划重新添加
+	r.syncNodes(ctx, &data, true, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *NodeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data NodeResourceModel
+	var data, state NodeResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var planNodes, stateNodes []NodeAddModel
+	resp.Diagnostics.Append(data.Nodes.ElementsAs(ctx, &planNodes, false)...)
+	resp.Diagnostics.Append(state.Nodes.ElementsAs(ctx, &stateNodes, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// 按节点名称比较新旧列表：只删除被移除的节点、只添加新增的节点
+	changes := diffNodes(stateNodes, planNodes)
+	if len(changes.changed) > 0 && data.NodeChangePolicy.ValueString() != nodeChangePolicyReplace {
+		resp.Diagnostics.AddError(
+			"Node change not supported",
+			fmt.Sprintf("The IP addresses, roles or GPU product of existing nodes %v cannot be changed in place. "+
+				"Set node_change_policy = \"replace\" to remove and re-add them.", changes.changedNames()),
+		)
+		return
+	}
+
+	clusterID := int(data.ClusterID.ValueInt64())
+
+	if len(changes.toDelete()) > 0 || len(changes.toAdd()) > 0 {
+		unlock, err := lockCluster(ctx, r.client, clusterID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Failed to lock cluster",
+				fmt.Sprintf("Unable to start an operation on cluster %d: %s", clusterID, err),
+			)
+			return
+		}
+		defer unlock()
+	}
+
+	if toDelete := changes.toDelete(); len(toDelete) > 0 {
+		tflog.Info(ctx, "Deleting nodes from cluster", map[string]interface{}{
+			"cluster_id": clusterID,
+			"node_names": toDelete,
+		})
+
+		err := r.client.DeleteNode(clusterID, toDelete)
+		if err != nil {
+			// 部分节点可能已被删除，按集群实际成员保存原有状态
+			r.syncNodes(ctx, &state, true, &resp.Diagnostics)
+			state.setID(ctx, &resp.Diagnostics)
+			resp.Diagnostics.AddError(
+				"Failed to delete nodes",
+				fmt.Sprintf("Unable to delete nodes %v from cluster %d, got error: %s", toDelete, clusterID, err),
+			)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+			return
+		}
+	}
+
+	if toAdd := changes.toAdd(); len(toAdd) > 0 {
+		addErr := r.addNodes(ctx, req.Config, &data, toAdd, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if addErr != nil {
+			missing, err := r.missingNodes(clusterID, toAdd)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Failed to add nodes",
+					fmt.Sprintf("Unable to add nodes to cluster %d, got error: %s", clusterID, addErr),
+				)
+				return
+			}
+
+			// 只保存已加入集群的节点，下次 apply 只重新添加失败的节点
+			joinDiags := nodeJoinFailures(toAdd, missing, clusterID, addErr, false)
+			r.syncNodes(ctx, &data, true, &resp.Diagnostics)
+			data.setID(ctx, &resp.Diagnostics)
+			resp.Diagnostics.Append(joinDiags...)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			return
+		}
+	}
+
+	// 其余属性（密码、License、容器运行时等）只在添加节点时使用，直接保存新值
+	r.syncNodes(ctx, &data, false, &resp.Diagnostics)
+	data.setID(ctx, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// 节点资源不支持更新操作
-	resp.Diagnostics.AddError(
-		"Update not supported",
-		"Node resource does not support update operations. Please destroy and recreate the resource to modify nodes.",
-	)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *NodeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -299,13 +737,24 @@ func (r *NodeResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		nodeNames = append(nodeNames, node.Name.ValueString())
 	}
 
+	clusterID := int(data.ClusterID.ValueInt64())
+	unlock, err := lockCluster(ctx, r.client, clusterID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to lock cluster",
+			fmt.Sprintf("Unable to start an operation on cluster %d: %s", clusterID, err),
+		)
+		return
+	}
+	defer unlock()
+
 	tflog.Debug(ctx, "Deleting nodes from cluster", map[string]interface{}{
-		"cluster_id": data.ClusterID.ValueInt64(),
+		"cluster_id": clusterID,
 		"node_names": nodeNames,
 	})
 
 	// 调用 SDK 删除节点
-	err := r.client.DeleteNode(int(data.ClusterID.ValueInt64()), nodeNames)
+	err = r.client.DeleteNode(clusterID, nodeNames)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to delete nodes", err.Error())
 		return
@@ -315,5 +764,529 @@ func (r *NodeResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 }
 
 func (r *NodeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	clusterID, names, err := parseNodeResourceID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing node ID",
+			fmt.Sprintf("Unable to parse node ID '%s': %s. Expected format: <cluster_id>:<name1>,<name2> or <cluster_id>:*", req.ID, err),
+		)
+		return
+	}
+
+	views, err := listClusterNodes(r.client, int(clusterID))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading nodes",
+			fmt.Sprintf("Unable to list nodes of cluster %d, got error: %s", clusterID, err),
+		)
+		return
+	}
+
+	// names 为 nil 表示导入全部非 Master 节点
+	var selected []view.NodeView
+	if names == nil {
+		for _, nodeView := range views {
+			if !slices.ContainsFunc(parseNodeRoles(nodeView.Role), func(role string) bool {
+				return strings.EqualFold(role, string(param.NodeRoleMaster))
+			}) {
+				selected = append(selected, nodeView)
+			}
+		}
+		if len(selected) == 0 {
+			resp.Diagnostics.AddError(
+				"No nodes to import",
+				fmt.Sprintf("Cluster %d has no non-master nodes.", clusterID),
+			)
+			return
+		}
+	} else {
+		nodeViews := make(map[string]view.NodeView, len(views))
+		for _, nodeView := range views {
+			nodeViews[nodeView.Name] = nodeView
+		}
+		for _, name := range names {
+			nodeView, ok := nodeViews[name]
+			if !ok {
+				resp.Diagnostics.AddError(
+					"Node not found",
+					fmt.Sprintf("Node %q does not exist in cluster %d.", name, clusterID),
+				)
+				continue
+			}
+			selected = append(selected, nodeView)
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	nodes := make([]NodeAddModel, 0, len(selected))
+	nodeNames := make([]string, 0, len(selected))
+	for _, nodeView := range selected {
+		roles, d := types.ListValueFrom(ctx, types.StringType, parseNodeRoles(nodeView.Role))
+		resp.Diagnostics.Append(d...)
+		nodes = append(nodes, NodeAddModel{
+			Name:       types.StringValue(nodeView.Name),
+			IP:         types.StringValue(nodeView.IP),
+			BusinessIP: types.StringNull(),
+			IP6:        types.StringNull(),
+			Port:       types.Int64Value(defaultSSHPort),
+			Roles:      roles,
+			GPUProduct: types.StringNull(),
+			Status:     types.StringValue(nodeView.Status),
+		})
+		nodeNames = append(nodeNames, nodeView.Name)
+	}
+
+	nodesType, d := resp.State.Schema.TypeAtPath(ctx, path.Root("nodes"))
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	nodesValue, d := types.ListValueFrom(ctx, nodesType.(types.ListType).ElemType, nodes)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), nodeResourceID(clusterID, nodeNames))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cluster_id"), clusterID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("nodes"), nodesValue)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("password_encoding"), passwordEncodingPlain)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("node_change_policy"), nodeChangePolicyError)...)
+}
+
+func (r *NodeResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	// 版本 0 的 ID 为 fmt.Sprintf("%v", nodeNames) 形式（例如 "[a b]"）。版本 0 没有 password_wo、
+	// password_wo_version、iluvatar_license_wo、iluvatar_license_wo_version、password_encoding、preflight、
+	// node_change_policy 和节点的 status，但其余属性都保留在当前 schema 中：旧 state 中缺少的属性解码为 null，
+	// 因此可以直接用当前 schema 作为 PriorSchema。删除或改变已有属性的类型时必须改为单独定义版本 0 的 schema。
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	priorSchema := schemaResp.Schema
+	priorSchema.Version = 0
+
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &priorSchema,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var data NodeResourceModel
+				resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				data.setID(ctx, &resp.Diagnostics)
+				if data.PasswordEncoding.IsNull() {
+					data.PasswordEncoding = types.StringValue(passwordEncodingPlain)
+				}
+				if data.NodeChangePolicy.IsNull() {
+					data.NodeChangePolicy = types.StringValue(nodeChangePolicyError)
+				}
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				tflog.Info(ctx, "Upgraded node resource state", map[string]interface{}{
+					"id": data.ID.ValueString(),
+				})
+				resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			},
+		},
+	}
+}
+
+// syncNodes 通过 PageNode 查询集群节点并更新每个节点的 status。
+// dropMissing 为 true 时，平台上已不存在的节点会从列表中移除；处于失败状态的节点会给出警告。
+func (r *NodeResource) syncNodes(ctx context.Context, data *NodeResourceModel, dropMissing bool, diags *diag.Diagnostics) {
+	clusterID := int(data.ClusterID.ValueInt64())
+
+	views, err := listClusterNodes(r.client, clusterID)
+	if err != nil {
+		diags.AddError(
+			"Error reading nodes",
+			fmt.Sprintf("Unable to list nodes of cluster %d, got error: %s", clusterID, err),
+		)
+		return
+	}
+
+	nodeViews := make(map[string]view.NodeView, len(views))
+	for _, nodeView := range views {
+		nodeViews[nodeView.Name] = nodeView
+	}
+
+	var nodes []NodeAddModel
+	diags.Append(data.Nodes.ElementsAs(ctx, &nodes, false)...)
+	if diags.HasError() {
+		return
+	}
+
+	synced := make([]NodeAddModel, 0, len(nodes))
+	for _, node := range nodes {
+		name := node.Name.ValueString()
+		nodeView, ok := nodeViews[name]
+		if !ok {
+			if dropMissing {
+				tflog.Warn(ctx, "Node no longer exists, removing from state", map[string]interface{}{
+					"cluster_id": clusterID,
+					"name":       name,
+				})
+				continue
+			}
+			node.Status = types.StringNull()
+			synced = append(synced, node)
+			continue
+		}
+
+		node.Status = types.StringValue(nodeView.Status)
+		if isNodeStatusFailed(nodeView.Status) {
+			diags.AddWarning(
+				"Node is unhealthy",
+				fmt.Sprintf("Node %q of cluster %d has status %q.", name, clusterID, nodeView.Status),
+			)
+		}
+		synced = append(synced, node)
+	}
+
+	nodesValue, d := types.ListValueFrom(ctx, data.Nodes.ElementType(ctx), synced)
+	diags.Append(d...)
+	if diags.HasError() {
+		return
+	}
+	data.Nodes = nodesValue
+}
+
+// addNodes 调用 AddNode 将给定节点加入集群，密码、License 等从配置中解析。
+// 参数错误写入 diags；AddNode 本身的错误直接返回，由调用方检查实际加入的节点。
+func (r *NodeResource) addNodes(ctx context.Context, config tfsdk.Config, data *NodeResourceModel, nodes []NodeAddModel, diags *diag.Diagnostics) error {
+	// write-only 属性只存在于配置中
+	password, d := resolveWriteOnlySecret(ctx, config, data.Password, "password_wo")
+	diags.Append(d...)
+	iluvatarLicense, d := resolveWriteOnlySecret(ctx, config, data.IluvatarLicense, "iluvatar_license_wo")
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil
+	}
+
+	password, err := prepareSSHPassword(r.client, password, data.PasswordEncoding.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("password_encoding"), "Invalid SSH password", err.Error())
+		return nil
+	}
+
+	// 构建添加节点参数
+	addParam := param.NodeAddParamOpenApi{
+		Password: password,
+		NodeAddParam: param.NodeAddParam{
+			ClusterID:        data.ClusterID.ValueInt64(),
+			Nodes:            make([]param.NodeAddObjParam, 0, len(nodes)),
+			ContainerRuntime: param.ContainerRuntime(data.ContainerRuntime.ValueString()),
+			DNSServer:        data.DNSServer.ValueString(),
+			IluvatarLicense:  iluvatarLicense,
+		},
+	}
+
+	// 解析镜像数据盘
+	var imageDataDisk map[string][]string
+	if !data.ImageDataDisk.IsNull() {
+		diags.Append(data.ImageDataDisk.ElementsAs(ctx, &imageDataDisk, false)...)
+		if diags.HasError() {
+			return nil
+		}
+	}
+
+	// 转换节点数据
+	nodeNames := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		var roles []string
+		diags.Append(node.Roles.ElementsAs(ctx, &roles, false)...)
+		if diags.HasError() {
+			return nil
+		}
+
+		nodeRoles := make([]param.ClusterNodeRole, 0, len(roles))
+		for _, role := range roles {
+			nodeRoles = append(nodeRoles, param.ClusterNodeRole(role))
+		}
+
+		name := node.Name.ValueString()
+		addParam.Nodes = append(addParam.Nodes, param.NodeAddObjParam{
+			Name:       name,
+			IP:         node.IP.ValueString(),
+			BusinessIp: node.BusinessIP.ValueString(),
+			IP6:        node.IP6.ValueString(),
+			Port:       int(node.Port.ValueInt64()),
+			Roles:      nodeRoles,
+			GPUProduct: param.GPUProduct(node.GPUProduct.ValueString()),
+		})
+		nodeNames = append(nodeNames, name)
+
+		// 只提交本次添加的节点的镜像数据盘
+		if disks, ok := imageDataDisk[name]; ok {
+			if addParam.ImageDataDisk == nil {
+				addParam.ImageDataDisk = make(map[string][]string)
+			}
+			addParam.ImageDataDisk[name] = disks
+		}
+	}
+
+	tflog.Debug(ctx, "Adding nodes to cluster", map[string]interface{}{
+		"cluster_id": addParam.ClusterID,
+		"node_count": len(addParam.Nodes),
+		"node_names": nodeNames,
+	})
+
+	// 调用 SDK 添加节点，等待安装完成
+	_, err = r.client.AddNode(int(data.ClusterID.ValueInt64()), addParam, false)
+	return err
+}
+
+// checkMemberCollisions 检查要添加的节点名称和 IP 是否与集群现有成员冲突，deleting 中的节点会先被删除，不参与检查
+func (r *NodeResource) checkMemberCollisions(clusterID int, nodes []NodeAddModel, deleting []string, diags *diag.Diagnostics) {
+	views, err := listClusterNodes(r.client, clusterID)
+	if err != nil {
+		// 集群不存在等错误留到 apply 时报告
+		return
+	}
+
+	memberByName := make(map[string]view.NodeView, len(views))
+	memberByIP := make(map[string]view.NodeView, len(views))
+	for _, nodeView := range views {
+		if slices.Contains(deleting, nodeView.Name) {
+			continue
+		}
+		memberByName[nodeView.Name] = nodeView
+		memberByIP[nodeView.IP] = nodeView
+	}
+
+	for _, node := range nodes {
+		if node.Name.IsUnknown() || node.IP.IsUnknown() {
+			continue
+		}
+
+		name := node.Name.ValueString()
+		if member, ok := memberByName[name]; ok {
+			diags.AddError(
+				"Node already exists",
+				fmt.Sprintf("Cluster %d already has a node named %q (%s). Choose another name, or import it with "+
+					"terraform import using the ID \"%d:%s\".", clusterID, name, member.IP, clusterID, name),
+			)
+			continue
+		}
+		if member, ok := memberByIP[node.IP.ValueString()]; ok {
+			diags.AddError(
+				"Node IP already in use",
+				fmt.Sprintf("IP address %s of node %q is already used by node %q of cluster %d.",
+					node.IP.ValueString(), name, member.Name, clusterID),
+			)
+		}
+	}
+}
+
+// missingNodes 查询集群实际成员，返回给定节点中没有加入集群的节点名称
+func (r *NodeResource) missingNodes(clusterID int, nodes []NodeAddModel) ([]string, error) {
+	views, err := listClusterNodes(r.client, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	joined := make(map[string]bool, len(views))
+	for _, nodeView := range views {
+		joined[nodeView.Name] = true
+	}
+
+	var missing []string
+	for _, node := range nodes {
+		if name := node.Name.ValueString(); !joined[name] {
+			missing = append(missing, name)
+		}
+	}
+	return missing, nil
+}
+
+// nodeJoinFailures 为每个未加入集群的节点返回一条诊断，所有节点都已加入时 AddNode 的错误以警告报告。
+// created 为 true 时（Create）使用警告以免资源被 taint，否则（Update）使用错误。
+func nodeJoinFailures(nodes []NodeAddModel, missing []string, clusterID int, addErr error, created bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if len(missing) == 0 {
+		diags.AddWarning(
+			"Adding nodes reported an error",
+			fmt.Sprintf("All nodes joined cluster %d, but adding them returned an error: %s", clusterID, addErr),
+		)
+		return diags
+	}
+
+	for _, node := range nodes {
+		name := node.Name.ValueString()
+		if !slices.Contains(missing, name) {
+			continue
+		}
+
+		detail := fmt.Sprintf("Node %q (%s) was not added to cluster %d: %s. ", name, node.IP.ValueString(), clusterID, addErr)
+		if created {
+			diags.AddWarning(
+				"Node failed to join cluster",
+				detail+"The other nodes were added. The next terraform plan removes this node from state during refresh "+
+					"and plans to add it again; nodes that already joined are kept.",
+			)
+			continue
+		}
+		diags.AddError(
+			"Node failed to join cluster",
+			detail+"The nodes that joined have been saved; the next terraform apply will try to add this node again.",
+		)
+	}
+	return diags
+}
+
+// nodeChanges 新旧节点列表按名称比较的结果
+type nodeChanges struct {
+	added   []NodeAddModel // 新增的节点
+	removed []string       // 被移除的节点名称
+	changed []NodeAddModel // IP、角色等发生变化、需要重装的已有节点（新配置）
+}
+
+// diffNodes 按节点名称比较新旧节点列表
+func diffNodes(oldNodes, newNodes []NodeAddModel) nodeChanges {
+	var changes nodeChanges
+
+	oldByName := make(map[string]NodeAddModel, len(oldNodes))
+	for _, node := range oldNodes {
+		oldByName[node.Name.ValueString()] = node
+	}
+
+	newNames := make(map[string]bool, len(newNodes))
+	for _, node := range newNodes {
+		name := node.Name.ValueString()
+		newNames[name] = true
+
+		oldNode, ok := oldByName[name]
+		switch {
+		case !ok:
+			changes.added = append(changes.added, node)
+		case node.requiresReinstall(oldNode):
+			changes.changed = append(changes.changed, node)
+		}
+	}
+
+	for _, node := range oldNodes {
+		if name := node.Name.ValueString(); !newNames[name] {
+			changes.removed = append(changes.removed, name)
+		}
+	}
+
+	return changes
+}
+
+// changedNames 返回需要重装的节点名称
+func (c nodeChanges) changedNames() []string {
+	names := make([]string, 0, len(c.changed))
+	for _, node := range c.changed {
+		names = append(names, node.Name.ValueString())
+	}
+	return names
+}
+
+// toDelete 返回需要调用 DeleteNode 的节点名称
+func (c nodeChanges) toDelete() []string {
+	return append(append([]string{}, c.removed...), c.changedNames()...)
+}
+
+// toAdd 返回需要调用 AddNode 的节点
+func (c nodeChanges) toAdd() []NodeAddModel {
+	return append(append([]NodeAddModel{}, c.added...), c.changed...)
+}
+
+// requiresReinstall 判断已有节点的配置变化是否需要重新安装节点。
+// SSH 端口只在添加节点时使用，修改它不需要重装；导入的节点没有业务 IP、IPv6 和 GPU 信息，
+// 旧值为空时视为未变化；角色与顺序无关。
+func (m NodeAddModel) requiresReinstall(old NodeAddModel) bool {
+	changed := func(newValue, oldValue types.String) bool {
+		return !oldValue.IsNull() && !newValue.Equal(oldValue)
+	}
+
+	return !m.IP.Equal(old.IP) ||
+		changed(m.BusinessIP, old.BusinessIP) ||
+		changed(m.IP6, old.IP6) ||
+		changed(m.GPUProduct, old.GPUProduct) ||
+		!sameRoles(m.Roles, old.Roles)
+}
+
+// sameRoles 比较两个角色列表，忽略顺序和大小写
+func sameRoles(a, b types.List) bool {
+	if a.IsUnknown() || b.IsUnknown() || a.IsNull() || b.IsNull() {
+		return a.Equal(b)
+	}
+
+	normalize := func(l types.List) []string {
+		roles := make([]string, 0, len(l.Elements()))
+		for _, v := range l.Elements() {
+			if s, ok := v.(types.String); ok {
+				roles = append(roles, strings.ToLower(s.ValueString()))
+			}
+		}
+		slices.Sort(roles)
+		return roles
+	}
+
+	return slices.Equal(normalize(a), normalize(b))
+}
+
+// setID 根据集群 ID 和节点列表重新生成资源 ID
+func (m *NodeResourceModel) setID(ctx context.Context, diags *diag.Diagnostics) {
+	var nodes []NodeAddModel
+	diags.Append(m.Nodes.ElementsAs(ctx, &nodes, false)...)
+	if diags.HasError() {
+		return
+	}
+
+	names, _ := nodeNamesOf(nodes)
+	m.ID = types.StringValue(nodeResourceID(m.ClusterID.ValueInt64(), names))
+}
+
+// nodeNamesOf 返回节点名称列表，存在未知名称时 known 为 false
+func nodeNamesOf(nodes []NodeAddModel) (names []string, known bool) {
+	names = make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		if node.Name.IsUnknown() {
+			return nil, false
+		}
+		names = append(names, node.Name.ValueString())
+	}
+	return names, true
+}
+
+// nodeResourceID 生成节点资源 ID：<cluster_id>:<name1>,<name2>
+func nodeResourceID(clusterID int64, names []string) string {
+	return fmt.Sprintf("%d:%s", clusterID, strings.Join(names, ","))
+}
+
+// parseNodeResourceID 解析 <cluster_id>:<name1>,<name2> 格式的节点资源 ID。
+// <cluster_id>:* 表示集群中全部非 Master 节点，此时返回的 names 为 nil。
+func parseNodeResourceID(id string) (int64, []string, error) {
+	clusterIDStr, namesStr, ok := strings.Cut(id, ":")
+	if !ok {
+		return 0, nil, fmt.Errorf("missing node names")
+	}
+
+	clusterID, err := strconv.ParseInt(clusterIDStr, 10, 64)
+	if err != nil {
+		return 0, nil, fmt.Errorf("invalid cluster ID %q", clusterIDStr)
+	}
+
+	if strings.TrimSpace(namesStr) == "*" {
+		return clusterID, nil, nil
+	}
+
+	names := make([]string, 0)
+	for _, name := range strings.Split(namesStr, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return 0, nil, fmt.Errorf("missing node names")
+	}
+
+	return clusterID, names, nil
 }
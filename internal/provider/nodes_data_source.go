@@ -6,15 +6,30 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"zstack.io/edge-go-sdk/pkg/client"
 	"zstack.io/edge-go-sdk/pkg/param"
+	"zstack.io/edge-go-sdk/pkg/view"
 )
 
+// nodeFilterFields filter 块可以使用的字段，平台只支持按 ID、名称和 IP 查询
+var nodeFilterFields = map[string]queryFilterField[view.NodeView]{
+	"id":          {query: "id", value: func(n view.NodeView) string { return strconv.FormatInt(n.ID, 10) }},
+	"name":        {query: "name", value: func(n view.NodeView) string { return n.Name }},
+	"ip":          {query: "ip", value: func(n view.NodeView) string { return n.IP }},
+	"role":        {value: func(n view.NodeView) string { return n.Role }},
+	"status":      {value: func(n view.NodeView) string { return n.Status }},
+	"create_time": {value: func(n view.NodeView) string { return n.CreateTime.Format("2006-01-02 15:04:05") }},
+	"update_time": {value: func(n view.NodeView) string { return n.UpdateTime.Format("2006-01-02 15:04:05") }},
+}
+
 var _ datasource.DataSource = &NodesDataSource{}
 
 func NewNodesDataSource() datasource.DataSource {
@@ -29,6 +44,7 @@ type NodesDataSourceModel struct {
 	ClusterID types.Int64           `tfsdk:"cluster_id"`
 	Name      types.String          `tfsdk:"name"`
 	Nodes     []NodeDataSourceModel `tfsdk:"nodes"`
+	Filter    []QueryFilterModel    `tfsdk:"filter"`
 }
 
 type NodeDataSourceModel struct {
@@ -115,6 +131,10 @@ func (d *NodesDataSource) Schema(ctx context.Context, req datasource.SchemaReque
 				},
 			},
 		},
+
+		Blocks: map[string]schema.Block{
+			"filter": queryFilterBlock(nodeFilterFields),
+		},
 	}
 }
 
@@ -148,13 +168,22 @@ func (d *NodesDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	if !data.Name.IsNull() {
 		queryParam.AddQ("name=" + data.Name.ValueString())
 	}
+	match, err := applyQueryFilters(data.Filter, nodeFilterFields, &queryParam)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("filter"), "Invalid filter", err.Error())
+		return
+	}
 
-	// 查询节点列表
-	nodes, _, err := d.client.PageNode(int(data.ClusterID.ValueInt64()), queryParam)
+	// 查询节点列表（自动分页）
+	clusterID := int(data.ClusterID.ValueInt64())
+	nodes, _, err := pageAll(queryParam, 0, func(queryParam param.QueryParam) ([]view.NodeView, int, error) {
+		return d.client.PageNode(clusterID, queryParam)
+	})
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to query nodes", err.Error())
 		return
 	}
+	nodes = slices.DeleteFunc(nodes, func(node view.NodeView) bool { return !match(node) })
 
 	// 转换数据
 	data.Nodes = make([]NodeDataSourceModel, 0, len(nodes))
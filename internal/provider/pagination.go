@@ -0,0 +1,42 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"zstack.io/edge-go-sdk/pkg/param"
+)
+
+// listPageSize 自动分页查询时每页的数量
+const listPageSize = 100
+
+// pageAll 从 start 开始逐页调用 fetch，直到取完全部记录。
+// queryParam 中的查询条件和排序会用于每一页，返回全部记录及平台报告的总数。
+func pageAll[T any](queryParam param.QueryParam, start int, fetch func(param.QueryParam) ([]T, int, error)) ([]T, int, error) {
+	var all []T
+	for ; ; start += listPageSize {
+		queryParam.Start(start)
+		queryParam.Limit(listPageSize)
+
+		items, total, err := fetch(queryParam)
+		if err != nil {
+			return nil, 0, err
+		}
+		all = append(all, items...)
+
+		if len(items) < listPageSize || start+len(items) >= total {
+			return all, total, nil
+		}
+	}
+}
+
+// sortQuery 设置排序字段和方向。SDK 的 QueryParam.Sort("-field") 会把 "-field" 原样作为字段名发送，
+// 因此直接设置 sort 和 sortDirection
+func sortQuery(queryParam *param.QueryParam, field string, desc bool) {
+	direction := "asc"
+	if desc {
+		direction = "desc"
+	}
+	queryParam.Set("sort", field)
+	queryParam.Set("sortDirection", direction)
+}
@@ -0,0 +1,93 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/md5"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"zstack.io/edge-go-sdk/pkg/client"
+)
+
+const (
+	// passwordEncodingPlain 明文密码，由 SDK 使用 AccessKey Secret 加密后提交
+	passwordEncodingPlain = "plain"
+	// passwordEncodingEncrypted 已使用 AccessKey Secret 加密过的密码
+	passwordEncodingEncrypted = "encrypted"
+)
+
+// passwordEncodings password_encoding 的可选值
+var passwordEncodings = []string{passwordEncodingPlain, passwordEncodingEncrypted}
+
+// prepareSSHPassword 根据 password_encoding 返回可直接交给 SDK 的明文密码。
+// SDK 的 CreateCluster / AddNode / GetNodeDisk 总会对密码再加密一次，
+// 因此已加密的密码需要先解密，否则会被加密两次导致安装失败。
+func prepareSSHPassword(zeClient *client.ZeClient, password, encoding string) (string, error) {
+	secret := zeClient.GetAccessKeySecret()
+
+	switch encoding {
+	case "", passwordEncodingPlain:
+		if _, err := decryptByAccessKey(secret, password); err == nil {
+			return "", errors.New("the password looks like a value already encrypted with the provider access key secret. " +
+				"The provider encrypts the password itself, so it would be encrypted twice. " +
+				"Either pass the plaintext password, or set password_encoding = \"encrypted\"")
+		}
+		return password, nil
+
+	case passwordEncodingEncrypted:
+		plain, err := decryptByAccessKey(secret, password)
+		if err != nil {
+			return "", fmt.Errorf("the password cannot be decrypted with the provider access key secret (%s). "+
+				"Make sure it was encrypted with the same access key the provider is configured with, "+
+				"or pass the plaintext password with password_encoding = \"plain\"", err)
+		}
+		if _, err := decryptByAccessKey(secret, plain); err == nil {
+			return "", errors.New("the password was encrypted twice with the provider access key secret. " +
+				"Encrypt the plaintext password only once, or pass the plaintext password with password_encoding = \"plain\"")
+		}
+		return plain, nil
+
+	default:
+		return "", fmt.Errorf("unsupported password_encoding %q, must be one of %v", encoding, passwordEncodings)
+	}
+}
+
+// decryptByAccessKey 是 SDK utils.EncryptByAccessKey 的逆操作：
+// AES-CBC，密钥为 AccessKey Secret 的 MD5 十六进制串，IV 为其前 16 字节，PKCS7 填充，标准 base64 编码。
+func decryptByAccessKey(key, value string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return "", errors.New("not valid base64")
+	}
+	if len(data) == 0 || len(data)%aes.BlockSize != 0 {
+		return "", errors.New("invalid ciphertext length")
+	}
+
+	sum := []byte(fmt.Sprintf("%x", md5.Sum([]byte(key))))
+	block, err := aes.NewCipher(sum)
+	if err != nil {
+		return "", err
+	}
+
+	plain := make([]byte, len(data))
+	cipher.NewCBCDecrypter(block, sum[:aes.BlockSize]).CryptBlocks(plain, data)
+
+	padding := int(plain[len(plain)-1])
+	if padding == 0 || padding > aes.BlockSize || !bytes.Equal(plain[len(plain)-padding:], bytes.Repeat([]byte{byte(padding)}, padding)) {
+		return "", errors.New("invalid padding")
+	}
+	plain = plain[:len(plain)-padding]
+
+	if !utf8.Valid(plain) {
+		return "", errors.New("decrypted value is not valid text")
+	}
+
+	return string(plain), nil
+}
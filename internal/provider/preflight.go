@@ -0,0 +1,121 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"zstack.io/edge-go-sdk/pkg/client"
+)
+
+// preflightHost 预检时需要通过 SSH 检查的主机
+type preflightHost struct {
+	name  string
+	ip    string
+	port  int
+	disks []string // data_disk 与 image_data_disk 中为该主机指定的磁盘
+}
+
+// preflightChecker 收集预检发现的所有问题，最后一次性报告，
+// 避免用户逐个修复、反复 plan。
+type preflightChecker struct {
+	client   *client.ZeClient
+	findings []string
+}
+
+func newPreflightChecker(zeClient *client.ZeClient) *preflightChecker {
+	return &preflightChecker{client: zeClient}
+}
+
+func (c *preflightChecker) addFinding(format string, args ...interface{}) {
+	c.findings = append(c.findings, fmt.Sprintf(format, args...))
+}
+
+// checkHosts 对每台主机调用 GetNodeDisk，验证 SSH 可以登录，并检查指定的磁盘存在且未被使用。
+// 各主机并发检查，结果按主机顺序汇总。
+func (c *preflightChecker) checkHosts(ctx context.Context, password string, hosts []preflightHost) {
+	results := make([][]string, len(hosts))
+
+	var wg sync.WaitGroup
+	for i, host := range hosts {
+		wg.Add(1)
+		go func(i int, host preflightHost) {
+			defer wg.Done()
+			results[i] = c.checkHost(ctx, password, host)
+		}(i, host)
+	}
+	wg.Wait()
+
+	for _, findings := range results {
+		c.findings = append(c.findings, findings...)
+	}
+}
+
+func (c *preflightChecker) checkHost(ctx context.Context, password string, host preflightHost) []string {
+	tflog.Debug(ctx, "Running preflight check", map[string]interface{}{
+		"name": host.name,
+		"ip":   host.ip,
+		"port": host.port,
+	})
+
+	disks, err := c.client.GetNodeDisk(host.ip, host.port, password)
+	if err != nil {
+		return []string{fmt.Sprintf("node %q (%s:%d): SSH check failed: %s", host.name, host.ip, host.port, err)}
+	}
+
+	available := make(map[string]bool, len(disks))
+	for _, disk := range disks {
+		available[diskDevicePath(disk.Name)] = !disk.Used
+	}
+
+	var findings []string
+	for _, disk := range host.disks {
+		unused, ok := available[diskDevicePath(disk)]
+		switch {
+		case !ok:
+			findings = append(findings, fmt.Sprintf("node %q (%s): disk %s does not exist or is a system disk", host.name, host.ip, disk))
+		case !unused:
+			findings = append(findings, fmt.Sprintf("node %q (%s): disk %s is already in use", host.name, host.ip, disk))
+		}
+	}
+
+	return findings
+}
+
+// checkIluvatarLicense 向已有集群添加天数 GPU 节点且未提供 License 时，
+// 确认集群中已经保存了 License。
+func (c *preflightChecker) checkIluvatarLicense(clusterID int, nodeNames []string) {
+	if len(nodeNames) == 0 {
+		return
+	}
+
+	hasLicense, err := c.client.HasIluvatarLicense(clusterID)
+	if err != nil {
+		c.addFinding("cluster %d: unable to check the Iluvatar license: %s", clusterID, err)
+		return
+	}
+	if !hasLicense {
+		c.addFinding("cluster %d has no Iluvatar license, but Iluvatar GPU nodes %v are being added; set iluvatar_license_wo or iluvatar_license",
+			clusterID, nodeNames)
+	}
+}
+
+// report 将所有问题合并为一条错误诊断
+func (c *preflightChecker) report(diags *diag.Diagnostics) {
+	if len(c.findings) == 0 {
+		return
+	}
+
+	diags.AddError(
+		"Preflight checks failed",
+		fmt.Sprintf("The following problems would make the installation fail:\n\n  - %s\n\n"+
+			"Fix them, or set preflight = false to skip these checks.", strings.Join(c.findings, "\n  - ")),
+	)
+}
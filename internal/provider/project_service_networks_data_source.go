@@ -0,0 +1,257 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"zstack.io/edge-go-sdk/pkg/client"
+	"zstack.io/edge-go-sdk/pkg/param"
+	"zstack.io/edge-go-sdk/pkg/view"
+)
+
+var _ datasource.DataSource = &ProjectServiceNetworksDataSource{}
+
+func NewProjectServiceNetworksDataSource() datasource.DataSource {
+	return &ProjectServiceNetworksDataSource{}
+}
+
+type ProjectServiceNetworksDataSource struct {
+	client *client.ZeClient
+}
+
+type ProjectServiceNetworksDataSourceModel struct {
+	ClusterID types.Int64                            `tfsdk:"cluster_id"`
+	ProjectID types.Int64                            `tfsdk:"project_id"`
+	ReadyOnly types.Bool                             `tfsdk:"ready_only"`
+	Networks  []ProjectServiceNetworkDataSourceModel `tfsdk:"networks"`
+	IDs       []types.Int64                          `tfsdk:"ids"`
+	Filter    []QueryFilterModel                     `tfsdk:"filter"`
+}
+
+type ProjectServiceNetworkDataSourceModel struct {
+	ID                 types.Int64   `tfsdk:"id"`
+	Name               types.String  `tfsdk:"name"`
+	Description        types.String  `tfsdk:"description"`
+	Interface          types.String  `tfsdk:"interface"`
+	Type               types.String  `tfsdk:"type"`
+	Cidr               types.String  `tfsdk:"cidr"`
+	Gateway            types.String  `tfsdk:"gateway"`
+	Netmask            types.String  `tfsdk:"netmask"`
+	IpTotalNum         types.Int64   `tfsdk:"ip_total_num"`
+	IpUsedNum          types.Int64   `tfsdk:"ip_used_num"`
+	IpFreeNum          types.Int64   `tfsdk:"ip_free_num"`
+	UtilizationPercent types.Float64 `tfsdk:"utilization_percent"`
+	SpiderPoolReady    types.Bool    `tfsdk:"spider_pool_ready"`
+	MetallbReady       types.Bool    `tfsdk:"metallb_ready"`
+	CreateTime         types.String  `tfsdk:"create_time"`
+}
+
+func (d *ProjectServiceNetworksDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project_service_networks"
+}
+
+func (d *ProjectServiceNetworksDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "查询项目的服务（LoadBalancer）可以使用的外部网络及其 IP 使用情况。" +
+			"结果按剩余 IP 数量从多到少排序，`networks[0]` 即为剩余地址最多的网络。",
+
+		Attributes: map[string]schema.Attribute{
+			"cluster_id": schema.Int64Attribute{
+				MarkdownDescription: "集群 ID",
+				Required:            true,
+			},
+			"project_id": schema.Int64Attribute{
+				MarkdownDescription: "项目 ID",
+				Required:            true,
+			},
+			"ready_only": schema.BoolAttribute{
+				MarkdownDescription: "是否只返回 MetalLB 已就绪的网络，默认为 `false`",
+				Optional:            true,
+			},
+			"networks": schema.ListNestedAttribute{
+				MarkdownDescription: "项目可用的外部网络列表",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							MarkdownDescription: "外部网络 ID",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "外部网络名称",
+							Computed:            true,
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "外部网络描述",
+							Computed:            true,
+						},
+						"interface": schema.StringAttribute{
+							MarkdownDescription: "网卡接口名称",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "网络类型：`manager`（管理网络）或 `business`（业务网络）",
+							Computed:            true,
+						},
+						"cidr": schema.StringAttribute{
+							MarkdownDescription: "外部网络的 IPv4 CIDR",
+							Computed:            true,
+						},
+						"gateway": schema.StringAttribute{
+							MarkdownDescription: "网关地址",
+							Computed:            true,
+						},
+						"netmask": schema.StringAttribute{
+							MarkdownDescription: "子网掩码",
+							Computed:            true,
+						},
+						"ip_total_num": schema.Int64Attribute{
+							MarkdownDescription: "IP 池中的 IP 总数",
+							Computed:            true,
+						},
+						"ip_used_num": schema.Int64Attribute{
+							MarkdownDescription: "已使用的 IP 数量",
+							Computed:            true,
+						},
+						"ip_free_num": schema.Int64Attribute{
+							MarkdownDescription: "剩余可用的 IP 数量",
+							Computed:            true,
+						},
+						"utilization_percent": schema.Float64Attribute{
+							MarkdownDescription: "IP 使用率（百分比，保留两位小数）",
+							Computed:            true,
+						},
+						"spider_pool_ready": schema.BoolAttribute{
+							MarkdownDescription: "SpiderPool（容器组附加网络）是否就绪",
+							Computed:            true,
+						},
+						"metallb_ready": schema.BoolAttribute{
+							MarkdownDescription: "MetalLB（服务负载均衡）是否就绪",
+							Computed:            true,
+						},
+						"create_time": schema.StringAttribute{
+							MarkdownDescription: "创建时间",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"ids": schema.ListAttribute{
+				MarkdownDescription: "外部网络 ID 列表，顺序与 `networks` 相同",
+				Computed:            true,
+				ElementType:         types.Int64Type,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"filter": queryFilterBlock(externalNetworkFilterFields),
+		},
+	}
+}
+
+func (d *ProjectServiceNetworksDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.ZeClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.ZeClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ProjectServiceNetworksDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ProjectServiceNetworksDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterID := int(data.ClusterID.ValueInt64())
+	projectID := int(data.ProjectID.ValueInt64())
+
+	queryParam := param.NewQueryParam()
+	match, err := applyQueryFilters(data.Filter, externalNetworkFilterFields, &queryParam)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("filter"), "Invalid filter", err.Error())
+		return
+	}
+
+	networks, err := listProjectServiceNetworks(d.client, clusterID, projectID, queryParam)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to query project service networks",
+			fmt.Sprintf("Unable to query external networks available to services of project %d in cluster %d, got error: %s", projectID, clusterID, err),
+		)
+		return
+	}
+
+	// 剩余 IP 多的网络排在前面，数量相同时按 ID 排序，保证结果稳定
+	slices.SortStableFunc(networks, func(a, b view.ExternalNetworkView) int {
+		if c := cmp.Compare(b.IpTotalNum-b.IpUsedNum, a.IpTotalNum-a.IpUsedNum); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	data.Networks = make([]ProjectServiceNetworkDataSourceModel, 0, len(networks))
+	data.IDs = make([]types.Int64, 0, len(networks))
+	for _, network := range networks {
+		if data.ReadyOnly.ValueBool() && !network.MetallbReady {
+			continue
+		}
+		if !match(network) {
+			continue
+		}
+
+		cidr := network.Cidr
+		if prefix, ok := networkPrefix(&network); ok {
+			cidr = prefix.String()
+		}
+
+		data.Networks = append(data.Networks, ProjectServiceNetworkDataSourceModel{
+			ID:                 types.Int64Value(network.ID),
+			Name:               types.StringValue(network.Name),
+			Description:        types.StringValue(network.Description),
+			Interface:          types.StringValue(network.Iface),
+			Type:               types.StringValue(string(network.Type)),
+			Cidr:               types.StringValue(cidr),
+			Gateway:            types.StringValue(network.Gateway),
+			Netmask:            types.StringValue(network.Netmask),
+			IpTotalNum:         types.Int64Value(network.IpTotalNum),
+			IpUsedNum:          types.Int64Value(network.IpUsedNum),
+			IpFreeNum:          types.Int64Value(max(network.IpTotalNum-network.IpUsedNum, 0)),
+			UtilizationPercent: types.Float64Value(utilizationPercent(network.IpUsedNum, network.IpTotalNum)),
+			SpiderPoolReady:    types.BoolValue(network.SpiderPoolReady),
+			MetallbReady:       types.BoolValue(network.MetallbReady),
+			CreateTime:         types.StringValue(network.CreateTime.Format("2006-01-02 15:04:05")),
+		})
+		data.IDs = append(data.IDs, types.Int64Value(network.ID))
+	}
+
+	tflog.Debug(ctx, "Project service networks retrieved", map[string]interface{}{
+		"cluster_id": clusterID,
+		"project_id": projectID,
+		"count":      len(data.Networks),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
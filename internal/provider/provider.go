@@ -6,6 +6,7 @@ package provider
 import (
 	"context"
 
+	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/function"
@@ -21,6 +22,7 @@ import (
 var _ provider.Provider = &ZakuProvider{}
 var _ provider.ProviderWithFunctions = &ZakuProvider{}
 var _ provider.ProviderWithEphemeralResources = &ZakuProvider{}
+var _ provider.ProviderWithActions = &ZakuProvider{}
 
 // ZakuProvider defines the provider implementation.
 type ZakuProvider struct {
@@ -33,9 +35,9 @@ type ZakuProvider struct {
 // ZakuProviderModel describes the provider data model.
 // 定义 Provider 的配置数据模型
 type ZakuProviderModel struct {
-	Host        types.String `tfsdk:"host"`         // ZStack Edge 主机地址
-	AccessKey   types.String `tfsdk:"access_key"`   // 访问密钥
-	SecretKey   types.String `tfsdk:"secret_key"`   // 密钥
+	Host      types.String `tfsdk:"host"`       // ZStack Edge 主机地址
+	AccessKey types.String `tfsdk:"access_key"` // 访问密钥
+	SecretKey types.String `tfsdk:"secret_key"` // 密钥
 }
 
 func (p *ZakuProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -114,28 +116,48 @@ func (p *ZakuProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 	// 将客户端传递给 Data Sources 和 Resources
 	resp.DataSourceData = zeClient
 	resp.ResourceData = zeClient
+	resp.EphemeralResourceData = zeClient
+	resp.ActionData = zeClient
 }
 
 func (p *ZakuProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewClusterResource,
-		NewExternalNetworkResource,  // 外部网络资源
-		NewNodeResource,              // 节点资源
+		NewExternalNetworkResource,       // 外部网络资源
+		NewNodeResource,                  // 节点资源
+		NewClusterNodeResource,           // 单个集群节点资源
+		NewExternalNetworkIpPoolResource, // 外部网络 IP 池资源
+		NewExternalNetworkIPResource,     // 外部网络固定 IP 资源
 	}
 }
 
 func (p *ZakuProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
-	return []func() ephemeral.EphemeralResource{}
+	return []func() ephemeral.EphemeralResource{
+		NewClusterKubeconfigEphemeralResource, // 集群 kubeconfig 临时资源
+	}
+}
+
+func (p *ZakuProvider) Actions(ctx context.Context) []func() action.Action {
+	return []func() action.Action{
+		NewClusterRecreateAction, // 集群重装
+	}
 }
 
 func (p *ZakuProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	// 注册所有的 Data Sources
 	// 每个 Data Source 都需要在这里注册，Terraform 才能识别和使用
 	return []func() datasource.DataSource{
-		NewClusterDataSource,          // 单个集群数据源
-		NewClustersDataSource,         // 集群列表数据源
-		NewExternalNetworksDataSource, // 外部网络列表数据源
-		NewNodesDataSource,            // 节点列表数据源
+		NewClusterDataSource,                   // 单个集群数据源
+		NewClustersDataSource,                  // 集群列表数据源
+		NewClusterKubeconfigDataSource,         // 集群 kubeconfig 数据源
+		NewExternalNetworksDataSource,          // 外部网络列表数据源
+		NewExternalNetworkIpPoolsDataSource,    // 外部网络 IP 池数据源
+		NewExternalNetworkInterfacesDataSource, // 外部网络候选网卡数据源
+		NewProjectServiceNetworksDataSource,    // 项目服务可用外部网络数据源
+		NewNodesDataSource,                     // 节点列表数据源
+		NewNodeDisksDataSource,                 // 节点候选数据盘数据源
+		NewRepositoriesDataSource,              // 镜像仓库列表数据源
+		NewRepositoryImagesDataSource,          // 仓库镜像列表数据源
 	}
 }
 
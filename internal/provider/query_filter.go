@@ -0,0 +1,220 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"zstack.io/edge-go-sdk/pkg/param"
+)
+
+// queryFilterOperators filter 块支持的运算符及对应的平台查询条件（q）运算符
+var queryFilterOperators = map[string]string{
+	"=":        "=",
+	"!=":       "!=",
+	">":        ">",
+	">=":       ">=",
+	"<":        "<",
+	"<=":       "<=",
+	"like":     "~=",
+	"not like": "!~=",
+}
+
+// QueryFilterModel 列表数据源的 filter 块
+type QueryFilterModel struct {
+	Name     types.String `tfsdk:"name"`
+	Values   []string     `tfsdk:"values"`
+	Operator types.String `tfsdk:"operator"`
+}
+
+// queryFilterField 描述 filter 块可以使用的一个字段
+type queryFilterField[T any] struct {
+	// query 平台查询条件中的字段名，为空时只在本地过滤
+	query string
+	// value 返回记录中该字段的值，用于本地过滤
+	value func(T) string
+}
+
+// queryFilterBlock 返回列表数据源通用的 filter 块
+func queryFilterBlock[T any](fieldMap map[string]queryFilterField[T]) schema.ListNestedBlock {
+	fields := slices.Sorted(maps.Keys(fieldMap))
+	return schema.ListNestedBlock{
+		MarkdownDescription: "过滤条件，多个 filter 之间为“与”的关系。平台支持的字段会转换为查询条件，其余字段在本地过滤。" +
+			"可用的字段：" + "`" + strings.Join(fields, "`、`") + "`",
+		NestedObject: schema.NestedBlockObject{
+			Attributes: map[string]schema.Attribute{
+				"name": schema.StringAttribute{
+					MarkdownDescription: "字段名",
+					Required:            true,
+					Validators: []validator.String{
+						stringOneOf(fields...),
+					},
+				},
+				"values": schema.ListAttribute{
+					MarkdownDescription: "字段值。`=` 匹配其中任意一个值，`!=` 排除所有值，`like`/`not like` 使用 `%` 和 `_` 通配符，" +
+						"比较运算符只能指定一个值",
+					Required:    true,
+					ElementType: types.StringType,
+				},
+				"operator": schema.StringAttribute{
+					MarkdownDescription: "运算符：`=`（默认）、`!=`、`>`、`>=`、`<`、`<=`、`like` 或 `not like`。" +
+						"两边都是数字时按数值比较，否则按字符串比较",
+					Optional: true,
+					Validators: []validator.String{
+						stringOneOf(slices.Sorted(maps.Keys(queryFilterOperators))...),
+					},
+				},
+			},
+		},
+	}
+}
+
+// applyQueryFilters 将平台支持的过滤条件加入 queryParam，返回在本地检查其余条件的函数
+func applyQueryFilters[T any](filters []QueryFilterModel, fields map[string]queryFilterField[T], queryParam *param.QueryParam) (func(T) bool, error) {
+	var local []func(T) bool
+	for _, filter := range filters {
+		name := filter.Name.ValueString()
+		field, ok := fields[name]
+		if !ok {
+			return nil, fmt.Errorf("unsupported filter name %q, supported names: %s", name, strings.Join(slices.Sorted(maps.Keys(fields)), ", "))
+		}
+
+		operator := "="
+		if !filter.Operator.IsNull() {
+			operator = filter.Operator.ValueString()
+		}
+		if _, ok := queryFilterOperators[operator]; !ok {
+			return nil, fmt.Errorf("unsupported operator %q in filter %q", operator, name)
+		}
+		if len(filter.Values) == 0 {
+			return nil, fmt.Errorf("filter %q requires at least one value", name)
+		}
+		if len(filter.Values) > 1 && isComparisonOperator(operator) {
+			return nil, fmt.Errorf("operator %q in filter %q accepts exactly one value, got %d", operator, name, len(filter.Values))
+		}
+
+		if q, ok := queryCondition(field.query, operator, filter.Values); ok {
+			queryParam.AddQ(q)
+			continue
+		}
+		match := filterMatcher(operator, filter.Values)
+		local = append(local, func(item T) bool {
+			return match(field.value(item))
+		})
+	}
+
+	return func(item T) bool {
+		for _, match := range local {
+			if !match(item) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+func isComparisonOperator(operator string) bool {
+	return operator == ">" || operator == ">=" || operator == "<" || operator == "<="
+}
+
+// queryCondition 将过滤条件转换为平台的 q 查询条件，如 name=foo、name?=a,b。
+// 字段不支持服务端查询或条件无法表达时返回 false
+func queryCondition(field, operator string, values []string) (string, bool) {
+	if field == "" || slices.ContainsFunc(values, func(value string) bool { return strings.Contains(value, ",") }) {
+		return "", false
+	}
+
+	if len(values) == 1 {
+		return field + queryFilterOperators[operator] + values[0], true
+	}
+
+	switch operator {
+	case "=":
+		return field + "?=" + strings.Join(values, ","), true
+	case "!=":
+		return field + "!?=" + strings.Join(values, ","), true
+	}
+	// 多个 like 条件之间为“或”的关系，q 条件无法表达
+	return "", false
+}
+
+// filterMatcher 返回在本地检查单个字段值的函数
+func filterMatcher(operator string, values []string) func(string) bool {
+	switch operator {
+	case "=":
+		return func(value string) bool { return slices.Contains(values, value) }
+	case "!=":
+		return func(value string) bool { return !slices.Contains(values, value) }
+	case "like", "not like":
+		patterns := make([]*regexp.Regexp, len(values))
+		for i, value := range values {
+			patterns[i] = likePattern(value)
+		}
+		matchAny := func(value string) bool {
+			return slices.ContainsFunc(patterns, func(re *regexp.Regexp) bool { return re.MatchString(value) })
+		}
+		if operator == "like" {
+			return matchAny
+		}
+		return func(value string) bool { return !matchAny(value) }
+	}
+
+	want := values[0]
+	return func(value string) bool {
+		c := compareFilterValues(value, want)
+		switch operator {
+		case ">":
+			return c > 0
+		case ">=":
+			return c >= 0
+		case "<":
+			return c < 0
+		default:
+			return c <= 0
+		}
+	}
+}
+
+// likePattern 将 SQL LIKE 表达式（% 匹配任意字符串，_ 匹配单个字符）转换为正则表达式
+func likePattern(pattern string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("^")
+	for _, r := range pattern {
+		switch r {
+		case '%':
+			b.WriteString(".*")
+		case '_':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
+
+// compareFilterValues 两边都是数字时按数值比较，否则按字符串比较
+func compareFilterValues(a, b string) int {
+	x, errA := strconv.ParseFloat(a, 64)
+	y, errB := strconv.ParseFloat(b, 64)
+	if errA == nil && errB == nil {
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(a, b)
+}
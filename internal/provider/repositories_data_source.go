@@ -0,0 +1,198 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"zstack.io/edge-go-sdk/pkg/client"
+	"zstack.io/edge-go-sdk/pkg/param"
+	"zstack.io/edge-go-sdk/pkg/view"
+)
+
+// repositoryFilterFields filter 块可以使用的字段，平台只支持按 ID 和名称查询
+var repositoryFilterFields = map[string]queryFilterField[view.RepositoryView]{
+	"id":          {query: "id", value: func(r view.RepositoryView) string { return strconv.FormatInt(r.ID, 10) }},
+	"name":        {query: "name", value: func(r view.RepositoryView) string { return r.Name }},
+	"type":        {value: func(r view.RepositoryView) string { return r.Type }},
+	"url":         {value: func(r view.RepositoryView) string { return r.URL }},
+	"status":      {value: func(r view.RepositoryView) string { return r.Status }},
+	"create_time": {value: func(r view.RepositoryView) string { return r.CreateTime.Format("2006-01-02 15:04:05") }},
+	"update_time": {value: func(r view.RepositoryView) string { return r.UpdateTime.Format("2006-01-02 15:04:05") }},
+}
+
+var _ datasource.DataSource = &RepositoriesDataSource{}
+
+func NewRepositoriesDataSource() datasource.DataSource {
+	return &RepositoriesDataSource{}
+}
+
+type RepositoriesDataSource struct {
+	client *client.ZeClient
+}
+
+type RepositoriesDataSourceModel struct {
+	ProjectID    types.Int64                 `tfsdk:"project_id"`
+	Name         types.String                `tfsdk:"name"`
+	Repositories []RepositoryDataSourceModel `tfsdk:"repositories"`
+	Filter       []QueryFilterModel          `tfsdk:"filter"`
+}
+
+type RepositoryDataSourceModel struct {
+	ID          types.Int64  `tfsdk:"id"`
+	ProjectID   types.Int64  `tfsdk:"project_id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	Type        types.String `tfsdk:"type"`
+	URL         types.String `tfsdk:"url"`
+	Status      types.String `tfsdk:"status"`
+	CreateTime  types.String `tfsdk:"create_time"`
+	UpdateTime  types.String `tfsdk:"update_time"`
+}
+
+func (d *RepositoriesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_repositories"
+}
+
+func (d *RepositoriesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "查询项目下的本地镜像仓库列表数据源。",
+
+		Attributes: map[string]schema.Attribute{
+			"project_id": schema.Int64Attribute{
+				MarkdownDescription: "项目 ID",
+				Required:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "仓库名称（可选，用于过滤）",
+				Optional:            true,
+			},
+			"repositories": schema.ListNestedAttribute{
+				MarkdownDescription: "镜像仓库列表",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							MarkdownDescription: "仓库 ID",
+							Computed:            true,
+						},
+						"project_id": schema.Int64Attribute{
+							MarkdownDescription: "项目 ID",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "仓库名称",
+							Computed:            true,
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "仓库描述",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "仓库类型",
+							Computed:            true,
+						},
+						"url": schema.StringAttribute{
+							MarkdownDescription: "仓库地址",
+							Computed:            true,
+						},
+						"status": schema.StringAttribute{
+							MarkdownDescription: "仓库状态",
+							Computed:            true,
+						},
+						"create_time": schema.StringAttribute{
+							MarkdownDescription: "创建时间",
+							Computed:            true,
+						},
+						"update_time": schema.StringAttribute{
+							MarkdownDescription: "更新时间",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"filter": queryFilterBlock(repositoryFilterFields),
+		},
+	}
+}
+
+func (d *RepositoriesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.ZeClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.ZeClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *RepositoriesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RepositoriesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// 构建查询参数
+	queryParam := param.NewQueryParam()
+	if !data.Name.IsNull() {
+		queryParam.AddQ("name=" + data.Name.ValueString())
+	}
+	match, err := applyQueryFilters(data.Filter, repositoryFilterFields, &queryParam)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("filter"), "Invalid filter", err.Error())
+		return
+	}
+
+	// 查询仓库列表（自动分页）
+	projectID := int(data.ProjectID.ValueInt64())
+	repositories, _, err := pageAll(queryParam, 0, func(queryParam param.QueryParam) ([]view.RepositoryView, int, error) {
+		return d.client.PageProjectRepository(projectID, queryParam)
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to query repositories",
+			fmt.Sprintf("Unable to query repositories of project %d, got error: %s", projectID, err),
+		)
+		return
+	}
+	repositories = slices.DeleteFunc(repositories, func(repository view.RepositoryView) bool { return !match(repository) })
+
+	// 转换数据
+	data.Repositories = make([]RepositoryDataSourceModel, 0, len(repositories))
+	for _, repository := range repositories {
+		data.Repositories = append(data.Repositories, RepositoryDataSourceModel{
+			ID:          types.Int64Value(repository.ID),
+			ProjectID:   types.Int64Value(repository.ProjectID),
+			Name:        types.StringValue(repository.Name),
+			Description: types.StringValue(repository.Description),
+			Type:        types.StringValue(repository.Type),
+			URL:         types.StringValue(repository.URL),
+			Status:      types.StringValue(repository.Status),
+			CreateTime:  types.StringValue(repository.CreateTime.Format("2006-01-02 15:04:05")),
+			UpdateTime:  types.StringValue(repository.UpdateTime.Format("2006-01-02 15:04:05")),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
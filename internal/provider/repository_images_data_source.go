@@ -0,0 +1,175 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"zstack.io/edge-go-sdk/pkg/client"
+	"zstack.io/edge-go-sdk/pkg/param"
+	"zstack.io/edge-go-sdk/pkg/view"
+)
+
+// repositoryImageFilterFields filter 块可以使用的字段，平台只支持按名称查询
+var repositoryImageFilterFields = map[string]queryFilterField[view.ImageView]{
+	"name":        {query: "name", value: func(i view.ImageView) string { return i.Name }},
+	"size":        {value: func(i view.ImageView) string { return strconv.FormatInt(i.Size, 10) }},
+	"tag_count":   {value: func(i view.ImageView) string { return strconv.Itoa(i.TagCount) }},
+	"create_time": {value: func(i view.ImageView) string { return i.CreateTime.Format("2006-01-02 15:04:05") }},
+	"update_time": {value: func(i view.ImageView) string { return i.UpdateTime.Format("2006-01-02 15:04:05") }},
+}
+
+var _ datasource.DataSource = &RepositoryImagesDataSource{}
+
+func NewRepositoryImagesDataSource() datasource.DataSource {
+	return &RepositoryImagesDataSource{}
+}
+
+type RepositoryImagesDataSource struct {
+	client *client.ZeClient
+}
+
+type RepositoryImagesDataSourceModel struct {
+	ProjectID    types.Int64                      `tfsdk:"project_id"`
+	RepositoryID types.Int64                      `tfsdk:"repository_id"`
+	Images       []RepositoryImageDataSourceModel `tfsdk:"images"`
+	Filter       []QueryFilterModel               `tfsdk:"filter"`
+}
+
+type RepositoryImageDataSourceModel struct {
+	Name         types.String `tfsdk:"name"`
+	RepositoryID types.Int64  `tfsdk:"repository_id"`
+	Size         types.Int64  `tfsdk:"size"`
+	TagCount     types.Int64  `tfsdk:"tag_count"`
+	CreateTime   types.String `tfsdk:"create_time"`
+	UpdateTime   types.String `tfsdk:"update_time"`
+}
+
+func (d *RepositoryImagesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_repository_images"
+}
+
+func (d *RepositoryImagesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "查询本地镜像仓库中的镜像列表数据源。",
+
+		Attributes: map[string]schema.Attribute{
+			"project_id": schema.Int64Attribute{
+				MarkdownDescription: "项目 ID",
+				Required:            true,
+			},
+			"repository_id": schema.Int64Attribute{
+				MarkdownDescription: "仓库 ID",
+				Required:            true,
+			},
+			"images": schema.ListNestedAttribute{
+				MarkdownDescription: "镜像列表",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "镜像名称",
+							Computed:            true,
+						},
+						"repository_id": schema.Int64Attribute{
+							MarkdownDescription: "仓库 ID",
+							Computed:            true,
+						},
+						"size": schema.Int64Attribute{
+							MarkdownDescription: "镜像大小（字节）",
+							Computed:            true,
+						},
+						"tag_count": schema.Int64Attribute{
+							MarkdownDescription: "镜像版本（tag）数量",
+							Computed:            true,
+						},
+						"create_time": schema.StringAttribute{
+							MarkdownDescription: "创建时间",
+							Computed:            true,
+						},
+						"update_time": schema.StringAttribute{
+							MarkdownDescription: "更新时间",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"filter": queryFilterBlock(repositoryImageFilterFields),
+		},
+	}
+}
+
+func (d *RepositoryImagesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.ZeClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.ZeClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *RepositoryImagesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RepositoryImagesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	queryParam := param.NewQueryParam()
+	match, err := applyQueryFilters(data.Filter, repositoryImageFilterFields, &queryParam)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("filter"), "Invalid filter", err.Error())
+		return
+	}
+
+	// 查询镜像列表（自动分页）
+	projectID := int(data.ProjectID.ValueInt64())
+	repositoryID := int(data.RepositoryID.ValueInt64())
+	images, _, err := pageAll(queryParam, 0, func(queryParam param.QueryParam) ([]view.ImageView, int, error) {
+		return d.client.PageRepositoryImage(projectID, repositoryID, queryParam)
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to query images",
+			fmt.Sprintf("Unable to query images of repository %d in project %d, got error: %s", repositoryID, projectID, err),
+		)
+		return
+	}
+	images = slices.DeleteFunc(images, func(image view.ImageView) bool { return !match(image) })
+
+	// 转换数据
+	data.Images = make([]RepositoryImageDataSourceModel, 0, len(images))
+	for _, image := range images {
+		data.Images = append(data.Images, RepositoryImageDataSourceModel{
+			Name:         types.StringValue(image.Name),
+			RepositoryID: types.Int64Value(image.RepositoryID),
+			Size:         types.Int64Value(image.Size),
+			TagCount:     types.Int64Value(int64(image.TagCount)),
+			CreateTime:   types.StringValue(image.CreateTime.Format("2006-01-02 15:04:05")),
+			UpdateTime:   types.StringValue(image.UpdateTime.Format("2006-01-02 15:04:05")),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,316 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// stringOneOfValidator 校验字符串属性为给定值之一
+type stringOneOfValidator struct {
+	values []string
+}
+
+var _ validator.String = stringOneOfValidator{}
+
+func stringOneOf(values ...string) validator.String {
+	return stringOneOfValidator{values: values}
+}
+
+func (v stringOneOfValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be one of: %s", strings.Join(v.values, ", "))
+}
+
+func (v stringOneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v stringOneOfValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if !slices.Contains(v.values, req.ConfigValue.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Attribute Value",
+			fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+// stringListOneOfValidator 校验字符串列表中的每个元素都为给定值之一
+type stringListOneOfValidator struct {
+	values []string
+}
+
+var _ validator.List = stringListOneOfValidator{}
+
+func stringListOneOf(values ...string) validator.List {
+	return stringListOneOfValidator{values: values}
+}
+
+func (v stringListOneOfValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("each element must be one of: %s", strings.Join(v.values, ", "))
+}
+
+func (v stringListOneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v stringListOneOfValidator) ValidateList(ctx context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	for i, elem := range req.ConfigValue.Elements() {
+		value, ok := elem.(types.String)
+		if !ok || value.IsNull() || value.IsUnknown() {
+			continue
+		}
+
+		if !slices.Contains(v.values, value.ValueString()) {
+			resp.Diagnostics.AddAttributeError(
+				req.Path.AtListIndex(i),
+				"Invalid Attribute Value",
+				fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), value.ValueString()),
+			)
+		}
+	}
+}
+
+// ipAddressValidator 校验字符串属性为合法的 IPv4 或 IPv6 地址
+type ipAddressValidator struct {
+	ipv6 bool
+}
+
+var _ validator.String = ipAddressValidator{}
+
+// ipv4Address 校验 IPv4 地址
+func ipv4Address() validator.String {
+	return ipAddressValidator{}
+}
+
+// ipv6Address 校验 IPv6 地址
+func ipv6Address() validator.String {
+	return ipAddressValidator{ipv6: true}
+}
+
+func (v ipAddressValidator) Description(ctx context.Context) string {
+	if v.ipv6 {
+		return "value must be a valid IPv6 address"
+	}
+	return "value must be a valid IPv4 address"
+}
+
+func (v ipAddressValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v ipAddressValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	addr, err := netip.ParseAddr(req.ConfigValue.ValueString())
+	if err != nil || addr.Is6() != v.ipv6 || addr.Zone() != "" {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Attribute Value",
+			fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+// ipv4NetmaskValidator 校验字符串属性为点分十进制的 IPv4 子网掩码，如 255.255.255.0
+type ipv4NetmaskValidator struct{}
+
+var _ validator.String = ipv4NetmaskValidator{}
+
+func ipv4Netmask() validator.String {
+	return ipv4NetmaskValidator{}
+}
+
+func (v ipv4NetmaskValidator) Description(ctx context.Context) string {
+	return "value must be a dotted IPv4 netmask with contiguous bits, such as 255.255.255.0"
+}
+
+func (v ipv4NetmaskValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v ipv4NetmaskValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, ok := netmaskBits(req.ConfigValue.ValueString()); !ok {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Attribute Value",
+			fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+// netmaskBits 将点分十进制子网掩码转换为前缀长度，掩码不合法时返回 false
+func netmaskBits(mask string) (int, bool) {
+	addr, err := netip.ParseAddr(mask)
+	if err != nil || !addr.Is4() {
+		return 0, false
+	}
+
+	b := addr.As4()
+	value := uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3])
+	bits := 0
+	for value&(1<<31) != 0 {
+		value <<= 1
+		bits++
+	}
+	// 前缀之后不能再有 1
+	if value != 0 {
+		return 0, false
+	}
+	return bits, true
+}
+
+// ipv4CIDRValidator 校验字符串属性为 IPv4 网段，且地址为网络地址（如 192.168.10.0/24）
+type ipv4CIDRValidator struct{}
+
+var _ validator.String = ipv4CIDRValidator{}
+
+func ipv4CIDR() validator.String {
+	return ipv4CIDRValidator{}
+}
+
+func (v ipv4CIDRValidator) Description(ctx context.Context) string {
+	return "value must be an IPv4 network in CIDR notation, such as 192.168.10.0/24"
+}
+
+func (v ipv4CIDRValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v ipv4CIDRValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	prefix, err := netip.ParsePrefix(req.ConfigValue.ValueString())
+	if err != nil || !prefix.Addr().Is4() || prefix != prefix.Masked() {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Attribute Value",
+			fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+// int64BetweenValidator 校验整数属性在 [min, max] 范围内
+type int64BetweenValidator struct {
+	min, max int64
+}
+
+var _ validator.Int64 = int64BetweenValidator{}
+
+func int64Between(min, max int64) validator.Int64 {
+	return int64BetweenValidator{min: min, max: max}
+}
+
+func (v int64BetweenValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be between %d and %d", v.min, v.max)
+}
+
+func (v int64BetweenValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v int64BetweenValidator) ValidateInt64(ctx context.Context, req validator.Int64Request, resp *validator.Int64Response) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if value := req.ConfigValue.ValueInt64(); value < v.min || value > v.max {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Attribute Value",
+			fmt.Sprintf("Attribute %s %s, got: %d", req.Path, v.Description(ctx), value),
+		)
+	}
+}
+
+// stringMatchesValidator 校验字符串属性匹配给定的正则表达式
+type stringMatchesValidator struct {
+	re      *regexp.Regexp
+	message string
+}
+
+var _ validator.String = stringMatchesValidator{}
+
+// stringMatches 校验字符串匹配正则表达式，message 描述期望的格式
+func stringMatches(re *regexp.Regexp, message string) validator.String {
+	return stringMatchesValidator{re: re, message: message}
+}
+
+func (v stringMatchesValidator) Description(ctx context.Context) string {
+	return v.message
+}
+
+func (v stringMatchesValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v stringMatchesValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if !v.re.MatchString(req.ConfigValue.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Attribute Value",
+			fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+// regexpValidator 校验字符串属性为合法的正则表达式
+type regexpValidator struct{}
+
+var _ validator.String = regexpValidator{}
+
+// validRegexp 校验正则表达式（Go RE2 语法）
+func validRegexp() validator.String {
+	return regexpValidator{}
+}
+
+func (v regexpValidator) Description(ctx context.Context) string {
+	return "value must be a valid regular expression (RE2 syntax)"
+}
+
+func (v regexpValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v regexpValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := regexp.Compile(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Attribute Value",
+			fmt.Sprintf("Attribute %s %s, got error: %s", req.Path, v.Description(ctx), err),
+		)
+	}
+}
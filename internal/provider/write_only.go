@@ -0,0 +1,58 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// resolveWriteOnlySecret 返回敏感值：优先使用 write-only 属性（只存在于配置中，
+// 不会出现在 plan 和 state 里），否则使用普通属性的值。
+func resolveWriteOnlySecret(ctx context.Context, config tfsdk.Config, value types.String, woAttr string) (string, diag.Diagnostics) {
+	var woValue types.String
+	diags := config.GetAttribute(ctx, path.Root(woAttr), &woValue)
+	if diags.HasError() {
+		return "", diags
+	}
+
+	if !woValue.IsNull() && !woValue.IsUnknown() {
+		return woValue.ValueString(), diags
+	}
+
+	return value.ValueString(), diags
+}
+
+// validateWriteOnlySecret 校验普通属性和对应的 write-only 属性不能同时设置，
+// required 为 true 时两者必须设置其一。
+func validateWriteOnlySecret(ctx context.Context, config tfsdk.Config, attr, woAttr string, required bool, diags *diag.Diagnostics) {
+	var value, woValue types.String
+	diags.Append(config.GetAttribute(ctx, path.Root(attr), &value)...)
+	diags.Append(config.GetAttribute(ctx, path.Root(woAttr), &woValue)...)
+	if diags.HasError() || value.IsUnknown() || woValue.IsUnknown() {
+		return
+	}
+
+	if !value.IsNull() && !woValue.IsNull() {
+		diags.AddAttributeError(
+			path.Root(woAttr),
+			"Conflicting Attributes",
+			fmt.Sprintf("Only one of %q and %q can be set. Prefer %q (Terraform 1.11+) so the value is never written to plan or state.", attr, woAttr, woAttr),
+		)
+		return
+	}
+
+	if required && value.IsNull() && woValue.IsNull() {
+		diags.AddAttributeError(
+			path.Root(attr),
+			"Missing Required Attribute",
+			fmt.Sprintf("One of %q or %q must be set.", attr, woAttr),
+		)
+	}
+}